@@ -0,0 +1,282 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"math"
+	"math/big"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"198/dex/quickswapv3"
+	"198/models"
+	"198/utils"
+)
+
+// PoolStats are the statistics of one pool over one rolling window, ending at BlockNumber.
+type PoolStats struct {
+	PoolKey     string         `json:"poolKey"`
+	DEX         string         `json:"dex"`
+	Window      uint32         `json:"window"` // Seconds
+	BlockNumber uint64         `json:"blockNumber"`
+	Swaps       int            `json:"swaps"`
+	Volume0     *models.Amount `json:"volume0"` // Token0 traded through the pool, either direction
+	Volume1     *models.Amount `json:"volume1"`
+	Fees0       *models.Amount `json:"fees0"` // Fees paid in token0 (on the input, or on the output for Curve)
+	Fees1       *models.Amount `json:"fees1"`
+	Dropped     uint64         `json:"dropped"` // Swap events lost by the subscription since startup, volumes and fees undercount when positive
+
+	// Algebra pools only, from the pool's own timepoints
+	VolatilityTicks    *float64 `json:"volatilityTicks,omitempty"`    // Standard deviation of the tick around its average (one tick is about 0.01%)
+	VolumePerLiquidity *float64 `json:"volumePerLiquidity,omitempty"` // Sum of sqrt(|amount0*amount1|)/liquidity over the pool's swaps
+}
+
+// swapRecord is the part of a swap the statistics need
+type swapRecord struct {
+	time    uint64
+	amount0 *big.Int // Pool perspective, as in EventData
+	amount1 *big.Int
+	fee0    *big.Int // Fee paid in token0
+	fee1    *big.Int
+}
+
+// Analytics keeps rolling statistics per pool: volumes and fees from our own Swap events,
+// volatility and volume per liquidity from the timepoints of Algebra pools.
+type Analytics struct {
+	caller     bind.ContractCaller
+	swaps      map[string][]swapRecord // Pool key -> swaps, oldest first
+	blockTimes map[uint64]uint64       // Block number -> timestamp of recent heads
+	lastTime   uint64                  // Timestamp of the latest head
+	retention  uint32                  // Longest window reported so far
+	dropped    uint64                  // Dropped swap events as of the previous report
+	stats      map[string][]PoolStats  // Pool key -> latest report, one entry per window
+	file       *os.File
+	encoder    *json.Encoder
+	mutex      sync.Mutex
+	reporting  atomic.Bool
+}
+
+// volumeScale is the Q64 scale of Algebra's volumePerLiquidity
+var volumeScale = new(big.Float).SetInt(new(big.Int).Lsh(big.NewInt(1), 64))
+
+// NewAnalytics appends every report to the JSON lines file at path.
+func NewAnalytics(caller bind.ContractCaller, path string) (*Analytics, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &Analytics{
+		caller:     caller,
+		swaps:      make(map[string][]swapRecord),
+		blockTimes: make(map[uint64]uint64),
+		retention:  86400,
+		stats:      make(map[string][]PoolStats),
+		file:       file,
+		encoder:    json.NewEncoder(file),
+	}, nil
+}
+
+// Run records swaps and block times until ctx is done or swaps is closed.
+func (a *Analytics) Run(ctx context.Context, swaps <-chan models.EventData, heads <-chan models.HeadEvent) {
+	defer a.file.Close()
+	for {
+		select {
+		case event, ok := <-swaps:
+			if !ok {
+				return
+			}
+			a.AddSwap(event)
+		case head := <-heads:
+			a.mutex.Lock()
+			a.blockTimes[head.BlockNumber] = head.Time
+			if head.Time > a.lastTime {
+				a.lastTime = head.Time
+			}
+			a.mutex.Unlock()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// AddSwap records a swap, timed by its block's head (or the latest head when its block was not seen).
+func (a *Analytics) AddSwap(event models.EventData) {
+	if event.Amount0 == nil || event.Amount1 == nil {
+		return
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	time, ok := a.blockTimes[event.BlockNumber]
+	if !ok {
+		time = a.lastTime
+	}
+	fee0, fee1 := swapFees(event)
+	a.swaps[event.PoolKey] = append(a.swaps[event.PoolKey], swapRecord{time: time, amount0: event.Amount0, amount1: event.Amount1, fee0: fee0, fee1: fee1})
+}
+
+// Report computes the statistics of every pool of snapshot over windows, ending at the head blockNumber.
+// dropped is the number of swap events the subscription feeding AddSwap lost so far, recorded with the reports.
+// The reports are persisted, logged for pools that traded, and kept for Stats.
+// A call made while the previous report still runs is skipped, so slow timepoint reads cannot pile up.
+func (a *Analytics) Report(ctx context.Context, snapshot *models.PoolSnapshot, windows []uint32, blockNumber uint64, dropped uint64) {
+	if !a.reporting.CompareAndSwap(false, true) {
+		log.Printf("Skipping the analytics report at block %v, the previous one is still running", blockNumber)
+		return
+	}
+	defer a.reporting.Store(false)
+
+	// Algebra timepoints are read first, outside the lock
+	timepoints := make(map[string][]*float64)
+	for _, pool := range snapshot.ListPools() {
+		if pool.DEX != "QuickswapV3" {
+			continue
+		}
+		volatility, volume, err := a.readTimepoints(ctx, pool, windows, blockNumber)
+		if err != nil {
+			log.Printf("Failed to read the timepoints of %v (%v): %v", pool.Key(), pool.DEX, err)
+			continue
+		}
+		timepoints[pool.Key()] = append(volatility, volume...)
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	now := a.lastTime
+	for _, window := range windows {
+		if window > a.retention {
+			a.retention = window
+		}
+	}
+	a.prune(now)
+	if dropped > a.dropped {
+		log.Printf("Analytics missed %v swap events since the last report, volumes and fees undercount", dropped-a.dropped)
+	}
+	a.dropped = dropped
+
+	for _, pool := range snapshot.ListPools() {
+		var reports []PoolStats
+		for k, window := range windows {
+			stats := a.windowStats(pool, window, now)
+			stats.BlockNumber, stats.Dropped = blockNumber, dropped
+			if values, ok := timepoints[pool.Key()]; ok {
+				stats.VolatilityTicks, stats.VolumePerLiquidity = values[k], values[len(windows)+k]
+			}
+			if err := a.encoder.Encode(stats); err != nil {
+				log.Printf("Failed to persist analytics of %v: %v", pool.Key(), err)
+			}
+			if stats.Swaps > 0 {
+				log.Printf("Analytics %v (%v) over %vs: %v swaps (volume: %v, %v) (fees: %v, %v)", stats.PoolKey, stats.DEX, window, stats.Swaps, stats.Volume0, stats.Volume1, stats.Fees0, stats.Fees1)
+			}
+			reports = append(reports, stats)
+		}
+		a.stats[pool.Key()] = reports
+	}
+}
+
+// Stats returns the latest report of a pool, one entry per window.
+func (a *Analytics) Stats(poolKey string) []PoolStats {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return append([]PoolStats(nil), a.stats[poolKey]...)
+}
+
+// windowStats sums the pool's swaps of the last window seconds before now
+func (a *Analytics) windowStats(pool *models.Pool, window uint32, now uint64) PoolStats {
+	volume0, volume1, fees0, fees1 := new(big.Int), new(big.Int), new(big.Int), new(big.Int)
+	stats := PoolStats{PoolKey: pool.Key(), DEX: pool.DEX, Window: window}
+	for _, swap := range a.swaps[pool.Key()] {
+		if swap.time+uint64(window) < now {
+			continue
+		}
+		stats.Swaps++
+		volume0.Add(volume0, new(big.Int).Abs(swap.amount0))
+		volume1.Add(volume1, new(big.Int).Abs(swap.amount1))
+		fees0.Add(fees0, swap.fee0)
+		fees1.Add(fees1, swap.fee1)
+	}
+	stats.Volume0, stats.Volume1 = models.NewAmount(pool.Token0, volume0), models.NewAmount(pool.Token1, volume1)
+	stats.Fees0, stats.Fees1 = models.NewAmount(pool.Token0, fees0), models.NewAmount(pool.Token1, fees1)
+	return stats
+}
+
+// prune drops the swaps older than the longest window
+func (a *Analytics) prune(now uint64) {
+	for poolKey, swaps := range a.swaps {
+		k := 0
+		for k < len(swaps) && swaps[k].time+uint64(a.retention) < now {
+			k++
+		}
+		a.swaps[poolKey] = swaps[k:]
+	}
+	for blockNumber, time := range a.blockTimes {
+		if time+uint64(a.retention) < now {
+			delete(a.blockTimes, blockNumber)
+		}
+	}
+}
+
+// readTimepoints averages the volatility and sums the volume per liquidity of an Algebra pool over each window
+func (a *Analytics) readTimepoints(ctx context.Context, pool *models.Pool, windows []uint32, blockNumber uint64) ([]*float64, []*float64, error) {
+	caller, err := quickswapv3.NewQuickswapv3Caller(common.HexToAddress(pool.Address), a.caller)
+	if err != nil {
+		return nil, nil, err
+	}
+	secondsAgos := append(append([]uint32(nil), windows...), 0)
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(blockNumber)}
+	timepoints, err := caller.GetTimepoints(opts, secondsAgos)
+	if err != nil {
+		return nil, nil, err
+	}
+	latest := len(windows)
+	if len(timepoints.VolatilityCumulatives) != len(secondsAgos) || len(timepoints.VolumePerAvgLiquiditys) != len(secondsAgos) {
+		return nil, nil, errors.New("unexpected timepoints response length")
+	}
+
+	volatility, volume := make([]*float64, len(windows)), make([]*float64, len(windows))
+	for k, window := range windows {
+		// volatilityCumulative sums (tick - averageTick)^2 every second
+		squared := new(big.Float).SetInt(new(big.Int).Sub(timepoints.VolatilityCumulatives[latest], timepoints.VolatilityCumulatives[k]))
+		variance, _ := squared.Quo(squared, big.NewFloat(float64(window))).Float64()
+		deviation := math.Sqrt(math.Max(variance, 0))
+		volatility[k] = &deviation
+
+		scaled := new(big.Float).SetInt(new(big.Int).Sub(timepoints.VolumePerAvgLiquiditys[latest], timepoints.VolumePerAvgLiquiditys[k]))
+		perLiquidity, _ := scaled.Quo(scaled, volumeScale).Float64()
+		volume[k] = &perLiquidity
+	}
+	return volatility, volume, nil
+}
+
+// swapFees splits the fee of a swap by token.
+// Curve fees (1e10 based) are taken from the output; every other DEX charges its fee (hundredths of a bip) on the input.
+func swapFees(event models.EventData) (*big.Int, *big.Int) {
+	fee0, fee1 := new(big.Int), new(big.Int)
+	if event.Fee == nil || event.Fee.Sign() <= 0 {
+		return fee0, fee1
+	}
+	if event.DEXSymbol == "Curve" {
+		// output = dy - dy*fee/1e10, so the fee is output*fee/(1e10-fee)
+		denominator := new(big.Int).Sub(big.NewInt(1e10), event.Fee)
+		if denominator.Sign() <= 0 {
+			return fee0, fee1
+		}
+		if event.Amount0.Sign() < 0 {
+			fee0.Quo(fee0.Mul(new(big.Int).Neg(event.Amount0), event.Fee), denominator)
+		} else if event.Amount1.Sign() < 0 {
+			fee1.Quo(fee1.Mul(new(big.Int).Neg(event.Amount1), event.Fee), denominator)
+		}
+		return fee0, fee1
+	}
+	if event.Amount0.Sign() > 0 {
+		fee0.Quo(fee0.Mul(event.Amount0, event.Fee), big.NewInt(utils.FeeDenominator))
+	} else if event.Amount1.Sign() > 0 {
+		fee1.Quo(fee1.Mul(event.Amount1, event.Fee), big.NewInt(utils.FeeDenominator))
+	}
+	return fee0, fee1
+}
@@ -0,0 +1,162 @@
+package candles
+
+import (
+	"log"
+	"math/big"
+	"time"
+
+	"198/models"
+)
+
+// Intervals are the candle lengths built from swaps.
+var Intervals = []time.Duration{time.Second, time.Minute, time.Hour}
+
+// Candle is the OHLCV summary of the trades of one series over one interval.
+// Prices are quote (second token) per base (first token) in whole tokens, at each swap's execution price (fee included).
+type Candle struct {
+	Start       int64 // Unix seconds
+	Open        float64
+	High        float64
+	Low         float64
+	Close       float64
+	BaseVolume  float64 // Whole base tokens traded, either direction
+	QuoteVolume float64
+	Trades      int64
+}
+
+// add folds one trade into the candle
+func (c *Candle) add(price, base, quote float64) {
+	if c.Trades == 0 {
+		c.Open, c.High, c.Low = price, price, price
+	}
+	if price > c.High {
+		c.High = price
+	}
+	if price < c.Low {
+		c.Low = price
+	}
+	c.Close = price
+	c.BaseVolume += base
+	c.QuoteVolume += quote
+	c.Trades++
+}
+
+// openKey identifies the candle being built for a series and interval
+type openKey struct {
+	series   string
+	interval time.Duration
+}
+
+// Aggregator turns swaps into per-pool and per-pair candles of every interval, writing each candle to the store once its interval ended.
+// Pool series are named by pool key with the pool's token order; pair series are named "A/B" with the symbols sorted, across all pools of the pair.
+type Aggregator struct {
+	store    *Store
+	pools    *models.PoolList
+	open     map[openKey]*Candle
+	times    map[uint64]uint64 // Block number -> timestamp of recent heads
+	lastTime uint64
+}
+
+// NewAggregator builds candles for the pools of pools into store.
+func NewAggregator(store *Store, pools *models.PoolList) *Aggregator {
+	return &Aggregator{
+		store: store,
+		pools: pools,
+		open:  make(map[openKey]*Candle),
+		times: make(map[uint64]uint64),
+	}
+}
+
+// Run aggregates swaps and closes the candles that ended at each head, until swaps is closed.
+// dropped returns the swaps lost before reaching swaps (e.g. Subscription.Dropped), logged at the heads where it grew.
+func (a *Aggregator) Run(swaps <-chan models.EventData, heads <-chan models.HeadEvent, dropped func() uint64) {
+	var reported uint64
+	for {
+		select {
+		case event, ok := <-swaps:
+			if !ok {
+				a.Flush(^uint64(0) >> 1)
+				return
+			}
+			a.AddSwap(event)
+		case head := <-heads:
+			a.times[head.BlockNumber] = head.Time
+			if head.Time > a.lastTime {
+				a.lastTime = head.Time
+			}
+			for blockNumber := range a.times {
+				if blockNumber+64 < head.BlockNumber {
+					delete(a.times, blockNumber)
+				}
+			}
+			a.Flush(head.Time)
+			if missed := dropped(); missed > reported {
+				log.Printf("Candles missed %v swaps, their candles undercount trades and volume", missed-reported)
+				reported = missed
+			}
+		}
+	}
+}
+
+// AddSwap adds a swap to the candles of its pool and pair, timed by its block's head (or the latest head when its block was not seen).
+func (a *Aggregator) AddSwap(event models.EventData) {
+	if event.Amount0 == nil || event.Amount1 == nil || event.Amount0.Sign() == 0 || event.Amount1.Sign() == 0 {
+		return
+	}
+	pool, err := a.pools.GetPoolByKey(event.PoolKey)
+	if err != nil {
+		return
+	}
+	timestamp, ok := a.times[event.BlockNumber]
+	if !ok {
+		timestamp = a.lastTime
+	}
+	if timestamp == 0 {
+		return
+	}
+
+	base := models.NewAmount(pool.Token0, new(big.Int).Abs(event.Amount0)).Float64()
+	quote := models.NewAmount(pool.Token1, new(big.Int).Abs(event.Amount1)).Float64()
+	a.add(pool.Key(), timestamp, quote/base, base, quote)
+
+	// Pairs are quoted in the symbol sorted last
+	if pool.Token0.Symbol < pool.Token1.Symbol {
+		a.add(pool.Token0.Symbol+"/"+pool.Token1.Symbol, timestamp, quote/base, base, quote)
+	} else {
+		a.add(pool.Token1.Symbol+"/"+pool.Token0.Symbol, timestamp, base/quote, quote, base)
+	}
+}
+
+// Flush writes and forgets the candles whose interval ended by now (Unix seconds).
+func (a *Aggregator) Flush(now uint64) {
+	for key, candle := range a.open {
+		if candle.Start+int64(key.interval/time.Second) <= int64(now) {
+			a.write(key, candle)
+			delete(a.open, key)
+		}
+	}
+}
+
+func (a *Aggregator) add(series string, timestamp uint64, price, base, quote float64) {
+	for _, interval := range Intervals {
+		key := openKey{series: series, interval: interval}
+		start := int64(timestamp) - int64(timestamp)%int64(interval/time.Second)
+		candle, ok := a.open[key]
+		if ok && start > candle.Start {
+			a.write(key, candle)
+			ok = false
+		}
+		if !ok {
+			candle = &Candle{Start: start}
+			a.open[key] = candle
+		}
+		// A swap timed before the open candle (late head) is folded into it
+		candle.add(price, base, quote)
+	}
+}
+
+func (a *Aggregator) write(key openKey, candle *Candle) {
+	if err := a.store.Append(key.series, key.interval, *candle); err != nil {
+		log.Printf("Failed to store %v candle of %v at %v: %v", key.interval, key.series, candle.Start, err)
+	}
+}
@@ -0,0 +1,37 @@
+package candles
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// WriteCSV writes candles with a header row, one row per candle.
+func WriteCSV(w io.Writer, series string, interval time.Duration, candles []Candle) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"series", "interval", "start", "time", "open", "high", "low", "close", "base_volume", "quote_volume", "trades"}); err != nil {
+		return err
+	}
+	format := func(value float64) string { return strconv.FormatFloat(value, 'g', -1, 64) }
+	for _, candle := range candles {
+		row := []string{
+			series,
+			interval.String(),
+			strconv.FormatInt(candle.Start, 10),
+			time.Unix(candle.Start, 0).UTC().Format(time.RFC3339),
+			format(candle.Open),
+			format(candle.High),
+			format(candle.Low),
+			format(candle.Close),
+			format(candle.BaseVolume),
+			format(candle.QuoteVolume),
+			strconv.FormatInt(candle.Trades, 10),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
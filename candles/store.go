@@ -0,0 +1,237 @@
+package candles
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// recordSize is the encoded size of a Candle: start, six float64 and the trade count, little endian
+const recordSize = 8 * 8
+
+var (
+	ErrOutOfOrder = errors.New("candle does not start after the last stored candle of its series")
+	ErrReadOnly   = errors.New("candle store is open read-only")
+)
+
+// Store is an embedded time-series store of candles: one append-only file of fixed-size records per series and interval,
+// at <dir>/<interval>/<escaped series>.bin. Records are sorted by start, so ranges are found by binary search.
+// Files are kept open for appending once written to, until Close.
+type Store struct {
+	dir   string
+	files map[string]*os.File // File path -> append handle
+	last  map[string]int64    // File path -> start of its last record
+	mutex sync.Mutex
+
+	readOnly bool
+}
+
+// OpenStore opens (and creates if needed) the store rooted at dir.
+func OpenStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &Store{dir: dir, files: make(map[string]*os.File), last: make(map[string]int64)}, nil
+}
+
+// OpenStoreReadOnly opens the existing store rooted at dir for reading only: nothing is created, and Append fails with ErrReadOnly.
+func OpenStoreReadOnly(dir string) (*Store, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("candle store %v is not a directory", dir)
+	}
+	return &Store{dir: dir, files: make(map[string]*os.File), last: make(map[string]int64), readOnly: true}, nil
+}
+
+// Append stores a candle after the existing candles of its series and interval.
+func (s *Store) Append(series string, interval time.Duration, candle Candle) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	path := s.path(series, interval)
+	file, ok := s.files[path]
+	if !ok {
+		var err error
+		if file, err = s.open(path); err != nil {
+			return err
+		}
+	}
+
+	if last, ok := s.last[path]; ok && candle.Start <= last {
+		return ErrOutOfOrder
+	}
+	if _, err := file.Write(encode(candle)); err != nil {
+		return err
+	}
+	s.last[path] = candle.Start
+	return nil
+}
+
+// open opens the file at path for appending and reads the start of its last record. Callers must hold s.mutex.
+func (s *Store) open(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	// A record cut short by a crash would misalign every later one
+	if info, err := file.Stat(); err != nil {
+		file.Close()
+		return nil, err
+	} else if torn := info.Size() % recordSize; torn != 0 {
+		if err := file.Truncate(info.Size() - torn); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+	if stored, err := lastRecord(file); err == nil {
+		s.last[path] = stored.Start
+	} else if err != io.EOF {
+		file.Close()
+		return nil, err
+	}
+	s.files[path] = file
+	return file, nil
+}
+
+// Close closes the files kept open for appending.
+func (s *Store) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var closeErr error
+	for path, file := range s.files {
+		if err := file.Close(); err != nil && closeErr == nil {
+			closeErr = err
+		}
+		delete(s.files, path)
+	}
+	return closeErr
+}
+
+// Range returns the candles of a series and interval starting in [from, to) (Unix seconds).
+func (s *Store) Range(series string, interval time.Duration, from, to int64) ([]Candle, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	file, err := os.Open(s.path(series, interval))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	count := int(info.Size() / recordSize)
+
+	// First record starting at or after from
+	var readErr error
+	first := sort.Search(count, func(k int) bool {
+		candle, err := readRecord(file, k)
+		if err != nil {
+			readErr = err
+			return true
+		}
+		return candle.Start >= from
+	})
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	var candles []Candle
+	for k := first; k < count; k++ {
+		candle, err := readRecord(file, k)
+		if err != nil {
+			return nil, err
+		}
+		if candle.Start >= to {
+			break
+		}
+		candles = append(candles, candle)
+	}
+	return candles, nil
+}
+
+// Series lists the series stored for an interval.
+func (s *Store) Series(interval time.Duration) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(s.dir, interval.String()))
+	if err != nil {
+		return nil, err
+	}
+	var series []string
+	for _, entry := range entries {
+		name, found := strings.CutSuffix(entry.Name(), ".bin")
+		if !found {
+			continue
+		}
+		if unescaped, err := url.PathUnescape(name); err == nil {
+			series = append(series, unescaped)
+		}
+	}
+	return series, nil
+}
+
+func (s *Store) path(series string, interval time.Duration) string {
+	return filepath.Join(s.dir, interval.String(), url.PathEscape(series)+".bin")
+}
+
+func encode(candle Candle) []byte {
+	record := make([]byte, recordSize)
+	binary.LittleEndian.PutUint64(record[0:], uint64(candle.Start))
+	for k, value := range []float64{candle.Open, candle.High, candle.Low, candle.Close, candle.BaseVolume, candle.QuoteVolume} {
+		binary.LittleEndian.PutUint64(record[8+8*k:], math.Float64bits(value))
+	}
+	binary.LittleEndian.PutUint64(record[56:], uint64(candle.Trades))
+	return record
+}
+
+func decode(record []byte) Candle {
+	value := func(k int) float64 { return math.Float64frombits(binary.LittleEndian.Uint64(record[8+8*k:])) }
+	return Candle{
+		Start:       int64(binary.LittleEndian.Uint64(record[0:])),
+		Open:        value(0),
+		High:        value(1),
+		Low:         value(2),
+		Close:       value(3),
+		BaseVolume:  value(4),
+		QuoteVolume: value(5),
+		Trades:      int64(binary.LittleEndian.Uint64(record[56:])),
+	}
+}
+
+func readRecord(file *os.File, index int) (Candle, error) {
+	record := make([]byte, recordSize)
+	if _, err := file.ReadAt(record, int64(index)*recordSize); err != nil {
+		return Candle{}, err
+	}
+	return decode(record), nil
+}
+
+// lastRecord reads the last complete record of a file, io.EOF when it has none
+func lastRecord(file *os.File) (Candle, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return Candle{}, err
+	}
+	count := int(info.Size() / recordSize)
+	if count == 0 {
+		return Candle{}, io.EOF
+	}
+	return readRecord(file, count-1)
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+
+	"198/models"
+)
+
+// bundledTokenList is the default token list of every chain (tokenlist.json), filtered by chain id
+//
+//go:embed tokenlist.json
+var bundledTokenList []byte
+
+// BundledHoldTags are the tags of the bundled token list marking tokens safe to hold.
+var BundledHoldTags = []string{"stablecoin"}
+
+// bundledTokens returns the bundled token list entries of a chain by symbol, for the default pools to reference.
+// The list is embedded in the binary, so an invalid list panics at startup.
+func bundledTokens(chainID uint64) map[string]*models.Token {
+	tokens, err := models.ParseTokenListJSON(bundledTokenList, chainID, BundledHoldTags)
+	if err != nil {
+		panic(fmt.Sprintf("bundled token list: %v", err))
+	}
+	bySymbol := make(map[string]*models.Token, len(tokens))
+	for _, token := range tokens {
+		bySymbol[token.Symbol] = token
+	}
+	return bySymbol
+}
+
+// DEXDeployment holds the contracts of one DEX on one chain.
+// For singleton designs (Balancer V2 Vault, Uniswap V4 PoolManager) Factory is the singleton itself.
+type DEXDeployment struct {
+	Factory string `json:"factory"`
+	Router  string `json:"router,omitempty"`
+	Quoter  string `json:"quoter,omitempty"` // Quoter contract used to cross-check local quotes (see crosscheck)
+}
+
+// Chain describes everything needed to run the bot on one network.
+type Chain struct {
+	Name          string
+	ChainID       uint64
+	EnvFile       string   // Env file holding NODE_URL and NODE_NAME for this chain
+	RPCEndpoints  []string // Used when the env file does not provide NODE_URL
+	NativeWrapper string   // Symbol of the wrapped native token
+	DEXes         map[string]DEXDeployment
+	Pools         []*models.Pool // Tokens are resolved by address against the bundled token list (see BuildPools)
+}
+
+// Chains is the registry of supported chains, keyed by the name used on the command line.
+var Chains = map[string]*Chain{
+	"polygon": {
+		Name:          "polygon",
+		ChainID:       137,
+		EnvFile:       ".env.polygon",
+		NativeWrapper: "WPOL",
+		DEXes: map[string]DEXDeployment{
+			"UniswapV3":   {Factory: "0x1F98431c8aD98523631AE4a59f267346ea31F984", Router: "0x68b3465833fb72a70ecdf485e0e4c7bd8665fc45", Quoter: "0x61fFE014bA17989E743c5F6cB21bF9697530B21e"},
+			"SushiswapV3": {Factory: "0x917933899c6a5F8E37F31E19f92CdBFF7e8FF0e2", Router: "0x0aF89E1620b96170e2a9D0b68fEebb767eD044c3", Quoter: "0xb1E835Dc2785b52265711e17fCCb0fd018226a6e"},
+			"QuickswapV3": {Factory: "0x411b0fAcC3489691f28ad58c47006AF5E3Ab3A28", Router: "0xf5b509bB0909a69B1c207E495f687a596C168E12", Quoter: "0xa15F0D7377B2A0C0c10db057f641beD21028FC89"},
+			"QuickswapV2": {Factory: "0x5757371414417b8C6CAad45bAeF941aBc7d3Ab32", Router: "0xa5E0829CaCEd8fFDD4De3c43696c57F7D7A678ff"},
+			"SushiswapV2": {Factory: "0xc35DADB65012eC5796536bD9864eD8773aBc74C4", Router: "0x1b02dA8Cb0d097eB8D57A175b88c7D8b47997506"},
+			"BalancerV2":  {Factory: "0xBA12222222228d8Ba445958a75a0704d566BF2C8"},
+			"UniswapV4":   {Factory: "0x67366782805870060151383F4BbFF9daB53e5cD6"},
+		},
+		Pools: InitialPools,
+	},
+	"ethereum": {
+		Name:          "ethereum",
+		ChainID:       1,
+		EnvFile:       ".env.ethereum",
+		NativeWrapper: "WETH",
+		DEXes: map[string]DEXDeployment{
+			"UniswapV3":   {Factory: "0x1F98431c8aD98523631AE4a59f267346ea31F984", Router: "0x68b3465833fb72a70ecdf485e0e4c7bd8665fc45", Quoter: "0x61fFE014bA17989E743c5F6cB21bF9697530B21e"},
+			"UniswapV2":   {Factory: "0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f", Router: "0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D"},
+			"SushiswapV2": {Factory: "0xC0AEe478e3658e2610c5F7A4A2E1777cE9e4f2Ac", Router: "0xd9e1cE17f2641f24aE83637ab66a2cca9C378B9F"},
+			"BalancerV2":  {Factory: "0xBA12222222228d8Ba445958a75a0704d566BF2C8"},
+			"UniswapV4":   {Factory: "0x000000000004444c5dc75cB358380D2e3dE08A90"},
+		},
+		Pools: EthereumPools,
+	},
+}
+
+// GetChain retrieves a chain from the registry by name.
+// Returns an error if the chain is not registered.
+func GetChain(name string) (*Chain, error) {
+	chain, exists := Chains[name]
+	if !exists {
+		return nil, errors.New("no chain registered with the given name")
+	}
+	return chain, nil
+}
+
+// BuildTokens returns the chain's default tokens: the tokens of the bundled token list deployed on the chain.
+func (c *Chain) BuildTokens() (*models.TokenList, error) {
+	return models.NewTokenListFromTokenListJSON(bundledTokenList, c.ChainID, BundledHoldTags)
+}
+
+// BuildPools returns copies of the chain's default pools, their tokens resolved by address against tokenList.
+// Returns an error if a pool references a token missing from tokenList.
+func (c *Chain) BuildPools(tokenList *models.TokenList) ([]*models.Pool, error) {
+	pools := make([]*models.Pool, 0, len(c.Pools))
+	for _, p := range c.Pools {
+		if p.Token0 == nil || p.Token1 == nil {
+			return nil, fmt.Errorf("pool %v: token missing from the bundled token list", p.Address)
+		}
+		token0, err := tokenList.GetTokenByAddress(p.Token0.Address)
+		if err != nil {
+			return nil, fmt.Errorf("pool %v: token0 %v: %w", p.Address, p.Token0.Symbol, err)
+		}
+		token1, err := tokenList.GetTokenByAddress(p.Token1.Address)
+		if err != nil {
+			return nil, fmt.Errorf("pool %v: token1 %v: %w", p.Address, p.Token1.Symbol, err)
+		}
+		pool := *p
+		pool.Token0, pool.Token1 = token0, token1
+		pools = append(pools, &pool)
+	}
+	return pools, nil
+}
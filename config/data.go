@@ -5,67 +5,20 @@ import (
 	"math/big"
 )
 
-var InitialTokens = []*models.Token{
-	{ // 0
-		Symbol:   "WBTC",
-		Address:  "0x1BFD67037B42Cf73acF2047067bd4F2C47D9BfD6",
-		Decimals: 6,
-		Hold:     false,
-		GasFee:   nil,
-	},
-	{ // 1
-		Symbol:   "WETH",
-		Address:  "0x7ceB23fD6bC0adD59E62ac25578270cFf1b9f619",
-		Decimals: 18,
-		Hold:     false,
-		GasFee:   nil,
-	},
-	{ // 2
-		Symbol:   "USDC",
-		Address:  "0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359",
-		Decimals: 6,
-		Hold:     true,
-		GasFee:   nil,
-	},
-	{ // 3
-		Symbol:   "USDT",
-		Address:  "0xc2132D05D31c914a87C6611C10748AEb04B58e8F",
-		Decimals: 6,
-		Hold:     true,
-		GasFee:   nil,
-	},
-	{ // 4
-		Symbol:   "WPOL",
-		Address:  "0x0d500B1d8E8eF31E21C99d1Db9A6444d3ADf1270",
-		Decimals: 18,
-		Hold:     false,
-		GasFee:   nil,
-	},
-	{ // 5
-		Symbol:   "DAI",
-		Address:  "0x8f3Cf7ad23Cd3CaDbD9735AFf958023239c6A063",
-		Decimals: 18,
-		Hold:     true,
-		GasFee:   nil,
-	},
-	{ // 6
-		Symbol:   "LINK",
-		Address:  "0x53E0bca35eC356BD5ddDFebbD1Fc0fD03FaBad39",
-		Decimals: 18,
-		Hold:     false,
-		GasFee:   nil,
-	},
-}
+// polygonTokens are the Polygon tokens of the bundled token list (tokenlist.json), by symbol
+var polygonTokens = bundledTokens(137)
 
 // TODO- Add more pools
+// NOTE: Multi-asset pools (e.g. Curve, BalancerV2) are listed once per token pair with ID set to models.EdgeID(address, token0, token1)
+// NOTE: UniswapV4 pools use the PoolManager as Address and their PoolId as ID, with TickSpacing and Hooks completing the PoolKey
 var InitialPools = []*models.Pool{
 	{
 		Address:               "0x50eaEDB835021E4A108B7290636d62E9765cc6d7",
 		RouterContractAddress: "0x68b3465833fb72a70ecdf485e0e4c7bd8665fc45",
 		DEX:                   "UniswapV3",
 		Fee:                   big.NewInt(500),
-		Token0:                InitialTokens[0], // WBTC
-		Token1:                InitialTokens[1], // WETH
+		Token0:                polygonTokens["WBTC"],
+		Token1:                polygonTokens["WETH"],
 	},
 	// TODO- WBTC scaling issue?
 	{
@@ -73,24 +26,24 @@ var InitialPools = []*models.Pool{
 		RouterContractAddress: "0x68b3465833fb72a70ecdf485e0e4c7bd8665fc45",
 		DEX:                   "UniswapV3",
 		Fee:                   big.NewInt(500),
-		Token0:                InitialTokens[0], // WBTC
-		Token1:                InitialTokens[2], // USDC
+		Token0:                polygonTokens["WBTC"],
+		Token1:                polygonTokens["USDC"],
 	},
 	{
 		Address:               "0x167384319B41F7094e62f7506409Eb38079AbfF8",
 		RouterContractAddress: "0x68b3465833fb72a70ecdf485e0e4c7bd8665fc45",
 		DEX:                   "UniswapV3",
 		Fee:                   big.NewInt(3000),
-		Token0:                InitialTokens[4], // WPOL
-		Token1:                InitialTokens[1], // WETH
+		Token0:                polygonTokens["WPOL"],
+		Token1:                polygonTokens["WETH"],
 	},
 	{
 		Address:               "0xA4D8c89f0c20efbe54cBa9e7e7a7E509056228D9",
 		RouterContractAddress: "0x68b3465833fb72a70ecdf485e0e4c7bd8665fc45",
 		DEX:                   "UniswapV3",
 		Fee:                   big.NewInt(500),
-		Token0:                InitialTokens[2], // USDC
-		Token1:                InitialTokens[1], // WETH
+		Token0:                polygonTokens["USDC"],
+		Token1:                polygonTokens["WETH"],
 	},
 	/*
 		{
@@ -98,8 +51,8 @@ var InitialPools = []*models.Pool{
 			RouterContractAddress: "0x68b3465833fb72a70ecdf485e0e4c7bd8665fc45",
 			DEX:                   "UniswapV3",
 			Fee:                   big.NewInt(500),
-			Token0:                InitialTokens[4],   // WPOL
-			Token1:                InitialTokens[100], // USDC.e
+			Token0:                polygonTokens["WPOL"],
+			Token1:                polygonTokens["USDC.e"],
 		},
 	*/
 	{
@@ -107,127 +60,176 @@ var InitialPools = []*models.Pool{
 		RouterContractAddress: "0x68b3465833fb72a70ecdf485e0e4c7bd8665fc45",
 		DEX:                   "UniswapV3",
 		Fee:                   big.NewInt(500),
-		Token0:                InitialTokens[4], // WPOL
-		Token1:                InitialTokens[1], // WETH
+		Token0:                polygonTokens["WPOL"],
+		Token1:                polygonTokens["WETH"],
 	},
 	{
 		Address:               "0x9b08288c3be4f62bbf8d1c20ac9c5e6f9467d8b7",
 		RouterContractAddress: "0x68b3465833fb72a70ecdf485e0e4c7bd8665fc45",
 		DEX:                   "UniswapV3",
 		Fee:                   big.NewInt(500),
-		Token0:                InitialTokens[4], // WPOL
-		Token1:                InitialTokens[3], // USDT
+		Token0:                polygonTokens["WPOL"],
+		Token1:                polygonTokens["USDT"],
 	},
 	{
 		Address:               "0xb6e57ed85c4c9dbfef2a68711e9d6f36c56e0fcb",
 		RouterContractAddress: "0x68b3465833fb72a70ecdf485e0e4c7bd8665fc45",
 		DEX:                   "UniswapV3",
 		Fee:                   big.NewInt(500),
-		Token0:                InitialTokens[4], // WPOL
-		Token1:                InitialTokens[2], // USDC
+		Token0:                polygonTokens["WPOL"],
+		Token1:                polygonTokens["USDC"],
 	},
 	{
 		Address:               "0x6b75F2189F0E11C52e814E09e280eb1a9A8A094a",
 		RouterContractAddress: "0x68b3465833fb72a70ecdf485e0e4c7bd8665fc45",
 		DEX:                   "UniswapV3",
 		Fee:                   big.NewInt(500),
-		Token0:                InitialTokens[4], // WPOL
-		Token1:                InitialTokens[0], // WBTC
+		Token0:                polygonTokens["WPOL"],
+		Token1:                polygonTokens["WBTC"],
 	},
 	{
 		Address:               "0x0f663c16Dd7C65cF87eDB9229464cA77aEea536b",
 		RouterContractAddress: "0x68b3465833fb72a70ecdf485e0e4c7bd8665fc45",
 		DEX:                   "UniswapV3",
 		Fee:                   big.NewInt(500),
-		Token0:                InitialTokens[4], // WPOL
-		Token1:                InitialTokens[5], // DAI
+		Token0:                polygonTokens["WPOL"],
+		Token1:                polygonTokens["DAI"],
 	},
 	{
 		Address:               "0x0A28C2F5E0E8463E047C203F00F649812aE67E4f",
 		RouterContractAddress: "0x68b3465833fb72a70ecdf485e0e4c7bd8665fc45",
 		DEX:                   "UniswapV3",
 		Fee:                   big.NewInt(500),
-		Token0:                InitialTokens[4], // WPOL
-		Token1:                InitialTokens[6], // LINK
+		Token0:                polygonTokens["WPOL"],
+		Token1:                polygonTokens["LINK"],
 	},
 	{
 		Address:               "0x052C9b8f41f3855225495E78532aaAD0f22a925C",
 		RouterContractAddress: "0x68b3465833fb72a70ecdf485e0e4c7bd8665fc45",
 		DEX:                   "UniswapV3",
 		Fee:                   big.NewInt(500),
-		Token0:                InitialTokens[2], // USDC
-		Token1:                InitialTokens[6], // LINK
+		Token0:                polygonTokens["USDC"],
+		Token1:                polygonTokens["LINK"],
 	},
 	{
 		Address:               "0xdb975b96828352880409e86d5aE93c23c924f812",
 		RouterContractAddress: "0xf5b509bB0909a69B1c207E495f687a596C168E12",
 		DEX:                   "QuickswapV3",
 		Fee:                   big.NewInt(1081), // TODO- does fee change?
-		Token0:                InitialTokens[0], // WBTC
-		Token1:                InitialTokens[2], // USDC
+		Token0:                polygonTokens["WBTC"],
+		Token1:                polygonTokens["USDC"],
 	},
 	{
 		Address:               "0xa6AeDF7c4Ed6e821E67a6BfD56FD1702aD9a9719",
 		RouterContractAddress: "0xf5b509bB0909a69B1c207E495f687a596C168E12",
 		DEX:                   "QuickswapV3",
 		Fee:                   big.NewInt(888),
-		Token0:                InitialTokens[2], // USDC
-		Token1:                InitialTokens[1], // WETH
+		Token0:                polygonTokens["USDC"],
+		Token1:                polygonTokens["WETH"],
 	},
 	{
 		Address:               "0x6669B4706cC152F359e947BCa68E263A87c52634",
 		RouterContractAddress: "0xf5b509bB0909a69B1c207E495f687a596C168E12",
 		DEX:                   "QuickswapV3",
 		Fee:                   big.NewInt(1419),
-		Token0:                InitialTokens[4], // WPOL
-		Token1:                InitialTokens[2], // USDC
+		Token0:                polygonTokens["WPOL"],
+		Token1:                polygonTokens["USDC"],
 	},
 	{
 		Address:               "0xc10a06863f858f67C2Cd46F1675eE029D3F7acd8",
 		RouterContractAddress: "0xf5b509bB0909a69B1c207E495f687a596C168E12",
 		DEX:                   "QuickswapV3",
 		Fee:                   big.NewInt(14913),
-		Token0:                InitialTokens[2], // USDC
-		Token1:                InitialTokens[6], // LINK
+		Token0:                polygonTokens["USDC"],
+		Token1:                polygonTokens["LINK"],
 	},
 	{
 		Address:               "0x479e1b71a702a595e19b6d5932cd5c863ab57ee0",
 		RouterContractAddress: "0xf5b509bB0909a69B1c207E495f687a596C168E12",
 		DEX:                   "QuickswapV3",
 		Fee:                   big.NewInt(900),
-		Token0:                InitialTokens[4], // WPOL
-		Token1:                InitialTokens[1], // WETH
+		Token0:                polygonTokens["WPOL"],
+		Token1:                polygonTokens["WETH"],
 	},
 	{
 		Address:               "0xac4494e30a85369e332bdb5230d6d694d4259dbc",
 		RouterContractAddress: "0xf5b509bB0909a69B1c207E495f687a596C168E12",
 		DEX:                   "QuickswapV3",
 		Fee:                   big.NewInt(478),
-		Token0:                InitialTokens[0], // WBTC
-		Token1:                InitialTokens[1], // WETH
+		Token0:                polygonTokens["WBTC"],
+		Token1:                polygonTokens["WETH"],
 	},
 	{
 		Address:               "0x5b41eedcfc8e0ae47493d4945aa1ae4fe05430ff",
 		RouterContractAddress: "0xf5b509bB0909a69B1c207E495f687a596C168E12",
 		DEX:                   "QuickswapV3",
 		Fee:                   big.NewInt(1419),
-		Token0:                InitialTokens[4], // WPOL
-		Token1:                InitialTokens[3], // USDT
+		Token0:                polygonTokens["WPOL"],
+		Token1:                polygonTokens["USDT"],
 	},
 	{
 		Address:               "0x9ceff2f5138fc59eb925d270b8a7a9c02a1810f2",
 		RouterContractAddress: "0xf5b509bB0909a69B1c207E495f687a596C168E12",
 		DEX:                   "QuickswapV3",
 		Fee:                   big.NewInt(887),
-		Token0:                InitialTokens[1], // WETH
-		Token1:                InitialTokens[3], // USDT
+		Token0:                polygonTokens["WETH"],
+		Token1:                polygonTokens["USDT"],
 	},
 	{
 		Address:               "0xab52931301078e2405c3a3ebb86e11ad0dfd2cfd",
 		RouterContractAddress: "0xf5b509bB0909a69B1c207E495f687a596C168E12",
 		DEX:                   "QuickswapV3",
 		Fee:                   big.NewInt(2885),
-		Token0:                InitialTokens[6], // LINK
-		Token1:                InitialTokens[1], // WETH
+		Token0:                polygonTokens["LINK"],
+		Token1:                polygonTokens["WETH"],
+	},
+	// UniswapV2-style pairs (constant product, 0.3% fee)
+	{
+		Address:               "0xadbF1854e5883eB8aa7BAf50705338739e558E5b",
+		RouterContractAddress: "0xa5E0829CaCEd8fFDD4De3c43696c57F7D7A678ff",
+		DEX:                   "QuickswapV2",
+		Fee:                   big.NewInt(3000),
+		Token0:                polygonTokens["WPOL"],
+		Token1:                polygonTokens["WETH"],
+	},
+	{
+		Address:               "0xF6422B997c7F54D1c6a6e103bcb1499EEA0a7046",
+		RouterContractAddress: "0xa5E0829CaCEd8fFDD4De3c43696c57F7D7A678ff",
+		DEX:                   "QuickswapV2",
+		Fee:                   big.NewInt(3000),
+		Token0:                polygonTokens["WETH"],
+		Token1:                polygonTokens["USDT"],
+	},
+	{
+		Address:               "0x604229c960e5CACF2aaEAc8Be68Ac07BA9dF81c3",
+		RouterContractAddress: "0xa5E0829CaCEd8fFDD4De3c43696c57F7D7A678ff",
+		DEX:                   "QuickswapV2",
+		Fee:                   big.NewInt(3000),
+		Token0:                polygonTokens["WPOL"],
+		Token1:                polygonTokens["USDT"],
+	},
+	{
+		Address:               "0xdC9232E2Df177d7a12FdFf6EcBAb114E2231198D",
+		RouterContractAddress: "0xa5E0829CaCEd8fFDD4De3c43696c57F7D7A678ff",
+		DEX:                   "QuickswapV2",
+		Fee:                   big.NewInt(3000),
+		Token0:                polygonTokens["WBTC"],
+		Token1:                polygonTokens["WETH"],
+	},
+	{
+		Address:               "0xc4e595acDD7d12feC385E5dA5D43160e8A0bAC0E",
+		RouterContractAddress: "0x1b02dA8Cb0d097eB8D57A175b88c7D8b47997506",
+		DEX:                   "SushiswapV2",
+		Fee:                   big.NewInt(3000),
+		Token0:                polygonTokens["WPOL"],
+		Token1:                polygonTokens["WETH"],
+	},
+	{
+		Address:               "0xc2755915a85C6f6c1C0F3a86ac8C058F11Caa9C9",
+		RouterContractAddress: "0x1b02dA8Cb0d097eB8D57A175b88c7D8b47997506",
+		DEX:                   "SushiswapV2",
+		Fee:                   big.NewInt(3000),
+		Token0:                polygonTokens["WETH"],
+		Token1:                polygonTokens["USDT"],
 	},
 }
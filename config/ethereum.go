@@ -0,0 +1,76 @@
+package config
+
+import (
+	"198/models"
+	"math/big"
+)
+
+// ethereumTokens are the Ethereum tokens of the bundled token list (tokenlist.json), by symbol
+var ethereumTokens = bundledTokens(1)
+
+var EthereumPools = []*models.Pool{
+	{
+		Address:               "0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640",
+		RouterContractAddress: "0x68b3465833fb72a70ecdf485e0e4c7bd8665fc45",
+		DEX:                   "UniswapV3",
+		Fee:                   big.NewInt(500),
+		Token0:                ethereumTokens["USDC"],
+		Token1:                ethereumTokens["WETH"],
+	},
+	{
+		Address:               "0xCBCdF9626bC03E24f779434178A73a0B4bad62eD",
+		RouterContractAddress: "0x68b3465833fb72a70ecdf485e0e4c7bd8665fc45",
+		DEX:                   "UniswapV3",
+		Fee:                   big.NewInt(3000),
+		Token0:                ethereumTokens["WBTC"],
+		Token1:                ethereumTokens["WETH"],
+	},
+	{
+		Address:               "0x5777d92f208679DB4b9778590Fa3CAB3aC9e2168",
+		RouterContractAddress: "0x68b3465833fb72a70ecdf485e0e4c7bd8665fc45",
+		DEX:                   "UniswapV3",
+		Fee:                   big.NewInt(100),
+		Token0:                ethereumTokens["DAI"],
+		Token1:                ethereumTokens["USDC"],
+	},
+	{
+		Address:               "0x4e68Ccd3E89f51C3074ca5072bbAC773960dFa36",
+		RouterContractAddress: "0x68b3465833fb72a70ecdf485e0e4c7bd8665fc45",
+		DEX:                   "UniswapV3",
+		Fee:                   big.NewInt(3000),
+		Token0:                ethereumTokens["WETH"],
+		Token1:                ethereumTokens["USDT"],
+	},
+	{
+		Address:               "0x3416cF6C708Da44DB2624D63ea0AAef7113527C6",
+		RouterContractAddress: "0x68b3465833fb72a70ecdf485e0e4c7bd8665fc45",
+		DEX:                   "UniswapV3",
+		Fee:                   big.NewInt(100),
+		Token0:                ethereumTokens["USDC"],
+		Token1:                ethereumTokens["USDT"],
+	},
+	{
+		Address:               "0xB4e16d0168e52d35CaCD2c6185b44281Ec28C9Dc",
+		RouterContractAddress: "0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D",
+		DEX:                   "UniswapV2",
+		Fee:                   big.NewInt(3000),
+		Token0:                ethereumTokens["USDC"],
+		Token1:                ethereumTokens["WETH"],
+	},
+	{
+		Address:               "0xA478c2975Ab1Ea89e8196811F51A7B7Ade33eB11",
+		RouterContractAddress: "0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D",
+		DEX:                   "UniswapV2",
+		Fee:                   big.NewInt(3000),
+		Token0:                ethereumTokens["DAI"],
+		Token1:                ethereumTokens["WETH"],
+	},
+	{
+		Address:               "0x06da0fd433C1A5d7a4faa01111c044910A184553",
+		RouterContractAddress: "0xd9e1cE17f2641f24aE83637ab66a2cca9C378B9F",
+		DEX:                   "SushiswapV2",
+		Fee:                   big.NewInt(3000),
+		Token0:                ethereumTokens["WETH"],
+		Token1:                ethereumTokens["USDT"],
+	},
+}
@@ -0,0 +1,318 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"198/models"
+)
+
+// FileConfig is the JSON configuration of one chain.
+// Pools reference their tokens by symbol, so entries can be added or reordered freely.
+type FileConfig struct {
+	TokenList *FileTokenList           `json:"tokenList,omitempty"`
+	Tokens    []FileToken              `json:"tokens"`
+	Pools     []FilePool               `json:"pools"`
+	DEXes     map[string]DEXDeployment `json:"dexes"`
+	Strategy  StrategyConfig           `json:"strategy"`
+
+	tokenListData []byte // Token-list document referenced by TokenList, read by LoadFile
+	chainID       uint64
+}
+
+// FileTokenList imports tokens from a community token-list document.
+// Entries of Tokens take precedence over token-list entries with the same symbol or address.
+type FileTokenList struct {
+	Path     string   `json:"path"`     // Relative to the configuration file
+	HoldTags []string `json:"holdTags"` // Tokens carrying one of these tags are marked Hold
+	Verify   bool     `json:"verify"`   // Cross-check the tokens against their erc20 contracts at startup
+}
+
+// FileToken is a token entry of the configuration file.
+type FileToken struct {
+	Symbol   string `json:"symbol"`
+	Address  string `json:"address"`
+	Decimals int    `json:"decimals"`
+	Hold     bool   `json:"hold"`
+}
+
+// FilePool is a pool entry of the configuration file.
+// Multi-asset pools may leave ID empty, it then defaults to models.EdgeID when the address is listed more than once.
+type FilePool struct {
+	DEX         string `json:"dex"`
+	Address     string `json:"address"`
+	ID          string `json:"id,omitempty"`
+	Router      string `json:"router,omitempty"`
+	Fee         int64  `json:"fee"`
+	Token0      string `json:"token0"`
+	Token1      string `json:"token1"`
+	TickSpacing int    `json:"tickSpacing,omitempty"`
+	Hooks       string `json:"hooks,omitempty"`
+}
+
+// StrategyConfig holds the strategy thresholds.
+type StrategyConfig struct {
+	MinimumMultiplier float64           `json:"minimumMultiplier"`    // Cumulative exchange rate a cycle must exceed (including gas fees)
+	Inventory         *InventoryConfig  `json:"inventory,omitempty"`  // Optional Hold token targets
+	CrossCheck        *CrossCheckConfig `json:"crossCheck,omitempty"` // Optional validation of local quotes against the DEXes' Quoter contracts
+	TWAP              *TWAPConfig       `json:"twap,omitempty"`       // Optional screening of opportunities against the pools' oracles
+	Analytics         *AnalyticsConfig  `json:"analytics,omitempty"`  // Optional rolling volume, fee and volatility statistics per pool
+}
+
+// InventoryConfig holds the target balances of the Hold tokens of a wallet.
+type InventoryConfig struct {
+	Wallet      string             `json:"wallet"`
+	Targets     map[string]float64 `json:"targets"`     // Hold token symbol -> target balance in whole tokens
+	Tolerance   float64            `json:"tolerance"`   // Relative drift tolerated before rebalancing (0.1 = 10%)
+	CheckBlocks uint64             `json:"checkBlocks"` // Blocks between balance checks (default 100)
+}
+
+// CrossCheckConfig sets how often and how strictly local quotes are compared with on-chain quotes.
+type CrossCheckConfig struct {
+	CheckBlocks uint64  `json:"checkBlocks"` // Blocks between rounds (default 50)
+	Samples     int     `json:"samples"`     // Random pool/size pairs quoted per round (default 5)
+	Tolerance   float64 `json:"tolerance"`   // Relative divergence above which a pool is flagged (default 0.0001 = 1 bip)
+}
+
+// TWAPConfig sets the oracle windows opportunities are screened against.
+type TWAPConfig struct {
+	Windows       []uint32 `json:"windows"`       // Averaging windows in seconds (default 300 and 1800)
+	MaxDeviation  int      `json:"maxDeviation"`  // Ticks the spot price may stray from any window's average (default 100, about 1%)
+	RefreshBlocks uint64   `json:"refreshBlocks"` // Blocks between oracle reads (default 10)
+}
+
+// AnalyticsConfig sets the rolling windows of the pool statistics.
+type AnalyticsConfig struct {
+	Windows      []uint32 `json:"windows"`      // Rolling windows in seconds (default 3600 and 86400)
+	ReportBlocks uint64   `json:"reportBlocks"` // Blocks between reports (default 100)
+}
+
+// DefaultStrategy is used for chains without a configuration file and for missing thresholds.
+var DefaultStrategy = StrategyConfig{
+	MinimumMultiplier: 1,
+}
+
+// LoadFile reads and validates the configuration file of the chain with the given id.
+// The referenced token list (if any) is read along, BuildTokens merges it with Tokens.
+func LoadFile(path string, chainID uint64) (*FileConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fc := &FileConfig{}
+	if err := json.Unmarshal(data, fc); err != nil {
+		return nil, err
+	}
+	if fc.Strategy.MinimumMultiplier == 0 {
+		fc.Strategy.MinimumMultiplier = DefaultStrategy.MinimumMultiplier
+	}
+	fc.chainID = chainID
+	if fc.TokenList != nil {
+		if fc.tokenListData, err = os.ReadFile(filepath.Join(filepath.Dir(path), fc.TokenList.Path)); err != nil {
+			return nil, fmt.Errorf("token list %v: %w", fc.TokenList.Path, err)
+		}
+	}
+
+	// Building into a scratch list catches unknown symbols and duplicates before anything is applied
+	tokenList, err := fc.BuildTokens()
+	if err != nil {
+		return nil, err
+	}
+	pools, err := fc.BuildPools(tokenList)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := models.NewPoolListFromSlice(pools); err != nil {
+		return nil, err
+	}
+
+	// Inventory targets only make sense for tokens cycles start and end in
+	if inventory := fc.Strategy.Inventory; inventory != nil {
+		for symbol := range inventory.Targets {
+			token, err := tokenList.GetTokenBySymbol(symbol)
+			if err != nil || !token.Hold {
+				return nil, fmt.Errorf("inventory target %q is not a Hold token", symbol)
+			}
+		}
+		if inventory.CheckBlocks == 0 {
+			inventory.CheckBlocks = 100
+		}
+	}
+	if crossCheck := fc.Strategy.CrossCheck; crossCheck != nil {
+		if crossCheck.CheckBlocks == 0 {
+			crossCheck.CheckBlocks = 50
+		}
+		if crossCheck.Samples == 0 {
+			crossCheck.Samples = 5
+		}
+		if crossCheck.Tolerance == 0 {
+			crossCheck.Tolerance = 0.0001
+		}
+	}
+	if twap := fc.Strategy.TWAP; twap != nil {
+		if len(twap.Windows) == 0 {
+			twap.Windows = []uint32{300, 1800}
+		}
+		for _, window := range twap.Windows {
+			if window == 0 {
+				return nil, errors.New("twap windows must be positive")
+			}
+		}
+		if twap.MaxDeviation == 0 {
+			twap.MaxDeviation = 100
+		}
+		if twap.RefreshBlocks == 0 {
+			twap.RefreshBlocks = 10
+		}
+	}
+	if analytics := fc.Strategy.Analytics; analytics != nil {
+		if len(analytics.Windows) == 0 {
+			analytics.Windows = []uint32{3600, 86400}
+		}
+		for _, window := range analytics.Windows {
+			if window == 0 {
+				return nil, errors.New("analytics windows must be positive")
+			}
+		}
+		if analytics.ReportBlocks == 0 {
+			analytics.ReportBlocks = 100
+		}
+	}
+	return fc, nil
+}
+
+// BuildTokens builds the token list: the tokens of the referenced token list (if any) deployed on the chain,
+// with the token entries added on top. An entry replaces a listed token with the same symbol or address.
+func (fc *FileConfig) BuildTokens() (*models.TokenList, error) {
+	tokenList := models.NewTokenList()
+	if fc.tokenListData != nil {
+		var err error
+		tokenList, err = models.NewTokenListFromTokenListJSON(fc.tokenListData, fc.chainID, fc.TokenList.HoldTags)
+		if err != nil {
+			return nil, fmt.Errorf("token list %v: %w", fc.TokenList.Path, err)
+		}
+	}
+
+	configured := make(map[string]bool)
+	for _, t := range fc.Tokens {
+		if configured[t.Symbol] {
+			return nil, fmt.Errorf("token %v: duplicate token symbol", t.Symbol)
+		}
+		if _, err := tokenList.GetTokenBySymbol(t.Symbol); err == nil {
+			tokenList.RemoveTokenBySymbol(t.Symbol)
+		}
+		if listed, err := tokenList.GetTokenByAddress(t.Address); err == nil && !configured[listed.Symbol] {
+			tokenList.RemoveTokenBySymbol(listed.Symbol)
+		}
+		configured[t.Symbol] = true
+		err := tokenList.AddToken(models.Token{
+			Symbol:   t.Symbol,
+			Address:  t.Address,
+			Decimals: t.Decimals,
+			Hold:     t.Hold,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("token %v: %w", t.Symbol, err)
+		}
+	}
+	return tokenList, nil
+}
+
+// BuildPools converts the pool entries into models.Pool values, resolving token symbols against tokenList.
+// Returns an error if a pool references an unknown token.
+func (fc *FileConfig) BuildPools(tokenList *models.TokenList) ([]*models.Pool, error) {
+	addressCount := make(map[string]int)
+	for _, p := range fc.Pools {
+		addressCount[p.Address]++
+	}
+
+	pools := make([]*models.Pool, 0, len(fc.Pools))
+	for _, p := range fc.Pools {
+		token0, err := tokenList.GetTokenBySymbol(p.Token0)
+		if err != nil {
+			return nil, fmt.Errorf("pool %v: token0 %q: %w", p.Address, p.Token0, err)
+		}
+		token1, err := tokenList.GetTokenBySymbol(p.Token1)
+		if err != nil {
+			return nil, fmt.Errorf("pool %v: token1 %q: %w", p.Address, p.Token1, err)
+		}
+		if p.DEX == "" || p.Address == "" {
+			return nil, errors.New("pool entry is missing its dex or address")
+		}
+
+		id := p.ID
+		if id == "" && addressCount[p.Address] > 1 {
+			id = models.EdgeID(p.Address, token0, token1)
+		}
+		pools = append(pools, &models.Pool{
+			Address:               p.Address,
+			ID:                    id,
+			DEX:                   p.DEX,
+			RouterContractAddress: p.Router,
+			Fee:                   big.NewInt(p.Fee),
+			Token0:                token0,
+			Token1:                token1,
+			TickSpacing:           p.TickSpacing,
+			Hooks:                 p.Hooks,
+		})
+	}
+	return pools, nil
+}
+
+// WatchFile calls onChange with the reloaded configuration every time the file at path is written.
+// The parent directory is watched so that editors replacing the file (rename + create) are seen too.
+// Invalid files are logged and skipped, keeping the previous configuration. Runs until done is closed.
+func WatchFile(path string, chainID uint64, done <-chan struct{}, onChange func(*FileConfig)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer watcher.Close()
+
+		// Editors emit several events per save, reload once they settle
+		debounce := time.NewTimer(time.Hour)
+		debounce.Stop()
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != filepath.Clean(path) || event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				debounce.Reset(250 * time.Millisecond)
+			case <-debounce.C:
+				fc, err := LoadFile(path, chainID)
+				if err != nil {
+					log.Printf("Ignoring invalid configuration %v: %v", path, err)
+					continue
+				}
+				onChange(fc)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("ERROR: watching configuration %v: %v", path, err)
+			case <-done:
+				return
+			}
+		}
+	}()
+	return nil
+}
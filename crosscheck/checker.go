@@ -0,0 +1,204 @@
+package crosscheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"math"
+	"math/big"
+	"math/rand"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"198/models"
+)
+
+// Sample is one local quote compared with the reference quote at the same block.
+type Sample struct {
+	PoolKey     string         `json:"poolKey"`
+	DEX         string         `json:"dex"`
+	BlockNumber uint64         `json:"blockNumber"`
+	AmountIn    *models.Amount `json:"amountIn"`
+	Local       *models.Amount `json:"local"`
+	Reference   *models.Amount `json:"reference"`
+	Divergence  float64        `json:"divergence"` // |local - reference| / reference
+	Flagged     bool           `json:"flagged"`
+}
+
+// PoolStats aggregates the samples of one pool.
+type PoolStats struct {
+	PoolKey        string
+	DEX            string
+	Samples        int
+	Flagged        int // Samples above the tolerance
+	Failures       int // Local or reference quotes that failed
+	MeanDivergence float64
+	MaxDivergence  float64
+}
+
+// Checker compares the local quoters of random pools and sizes with a Reference.
+type Checker struct {
+	reference Reference
+	random    *rand.Rand
+	stats     map[string]*PoolStats
+	file      *os.File
+	encoder   *json.Encoder
+	mutex     sync.Mutex // serializes rounds
+	running   atomic.Bool
+}
+
+// NewChecker appends every sample to the JSON lines file at path.
+func NewChecker(reference Reference, path string) (*Checker, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &Checker{
+		reference: reference,
+		random:    rand.New(rand.NewSource(time.Now().UnixNano())),
+		stats:     make(map[string]*PoolStats),
+		file:      file,
+		encoder:   json.NewEncoder(file),
+	}, nil
+}
+
+// SetReference replaces the reference, e.g. after the DEX deployments were reloaded.
+func (c *Checker) SetReference(reference Reference) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.reference = reference
+}
+
+// Round quotes samples random pool/size pairs both locally and with the reference, at the block of each pool's last event.
+// Samples whose pool changed while the reference was queried are dropped, as the two quotes no longer share a state.
+// Pools diverging by more than tolerance (relative) are flagged.
+// A call made while the previous round still runs is skipped and returns nil, so slow references cannot pile up rounds.
+func (c *Checker) Round(ctx context.Context, pools *models.PoolList, samples int, tolerance float64) []Sample {
+	if !c.running.CompareAndSwap(false, true) {
+		log.Printf("Skipping a cross-check round, the previous one is still running")
+		return nil
+	}
+	defer c.running.Store(false)
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var candidates []*models.Pool
+	for _, pool := range pools.ListPools() {
+		if pool.Quoter != nil && pool.BlockNumber > 0 && c.reference.Supports(pool.DEX) {
+			candidates = append(candidates, pool)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	var results []Sample
+	for k := 0; k < samples; k++ {
+		pool := candidates[c.random.Intn(len(candidates))]
+		tokenIn, tokenOut, reserveIn := pool.Token0, pool.Token1, pool.Reserve0
+		if c.random.Intn(2) == 1 {
+			tokenIn, tokenOut, reserveIn = pool.Token1, pool.Token0, pool.Reserve1
+		}
+		amountIn := c.randomSize(tokenIn, reserveIn)
+		if amountIn.Sign() <= 0 {
+			continue
+		}
+
+		stats := c.poolStats(pool)
+		local, err := pool.Quoter.AmountOut(amountIn, tokenOut)
+		if errors.Is(err, models.ErrInsufficientLiquidity) {
+			// Sizes crossing an initialized tick are not quoted locally, there is nothing to compare
+			continue
+		}
+		if err != nil {
+			stats.Failures++
+			log.Printf("Cross-check %v (%v): local quote of %v failed: %v", pool.Key(), pool.DEX, amountIn, err)
+			continue
+		}
+		reference, err := c.reference.QuoteExactInput(ctx, pool, amountIn, tokenOut, pool.BlockNumber)
+		if err != nil {
+			stats.Failures++
+			log.Printf("Cross-check %v (%v): reference quote of %v at block %v failed: %v", pool.Key(), pool.DEX, amountIn, pool.BlockNumber, err)
+			continue
+		}
+		if current, err := pools.GetPoolByKey(pool.Key()); err != nil || current != pool {
+			continue
+		}
+
+		sample := Sample{
+			PoolKey:     pool.Key(),
+			DEX:         pool.DEX,
+			BlockNumber: pool.BlockNumber,
+			AmountIn:    amountIn,
+			Local:       local,
+			Reference:   reference,
+			Divergence:  divergence(local.Raw, reference.Raw),
+		}
+		sample.Flagged = sample.Divergence > tolerance
+		stats.MeanDivergence = (stats.MeanDivergence*float64(stats.Samples) + sample.Divergence) / float64(stats.Samples+1)
+		stats.MaxDivergence = math.Max(stats.MaxDivergence, sample.Divergence)
+		stats.Samples++
+		if sample.Flagged {
+			stats.Flagged++
+			log.Printf("Cross-check %v (%v) flagged: %v -> %v locally vs %v on-chain at block %v (divergence: %.4f%%) (flagged: %v/%v)", sample.PoolKey, sample.DEX, amountIn, local, reference, sample.BlockNumber, sample.Divergence*100, stats.Flagged, stats.Samples)
+		}
+		if err := c.encoder.Encode(sample); err != nil {
+			log.Printf("Failed to persist cross-check sample of %v: %v", sample.PoolKey, err)
+		}
+		results = append(results, sample)
+	}
+	return results
+}
+
+// Stats returns the statistics of every sampled pool, most divergent first.
+func (c *Checker) Stats() []PoolStats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	stats := make([]PoolStats, 0, len(c.stats))
+	for _, poolStats := range c.stats {
+		stats = append(stats, *poolStats)
+	}
+	sort.Slice(stats, func(a, b int) bool { return stats[a].MaxDivergence > stats[b].MaxDivergence })
+	return stats
+}
+
+// Close closes the samples file.
+func (c *Checker) Close() error {
+	return c.file.Close()
+}
+
+func (c *Checker) poolStats(pool *models.Pool) *PoolStats {
+	stats, ok := c.stats[pool.Key()]
+	if !ok {
+		stats = &PoolStats{PoolKey: pool.Key(), DEX: pool.DEX}
+		c.stats[pool.Key()] = stats
+	}
+	return stats
+}
+
+// randomSize draws a log-uniform size: 1e-6 to 1e-2 of the (virtual) reserve when known, otherwise 0.01 to 100 whole tokens
+func (c *Checker) randomSize(token *models.Token, reserve *big.Int) *models.Amount {
+	base, low, high := new(big.Float).SetInt(models.OneToken(token).Raw), -2.0, 2.0
+	if reserve != nil && reserve.Sign() > 0 {
+		base, low, high = new(big.Float).SetInt(reserve), -6.0, -2.0
+	}
+	scale := math.Pow(10, low+c.random.Float64()*(high-low))
+	raw, _ := base.Mul(base, big.NewFloat(scale)).Int(nil)
+	return models.NewAmount(token, raw)
+}
+
+func divergence(local, reference *big.Int) float64 {
+	if reference.Sign() == 0 {
+		if local.Sign() == 0 {
+			return 0
+		}
+		return 1
+	}
+	difference := new(big.Float).SetInt(new(big.Int).Abs(new(big.Int).Sub(local, reference)))
+	ratio, _ := difference.Quo(difference, new(big.Float).SetInt(reference)).Float64()
+	return ratio
+}
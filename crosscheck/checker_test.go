@@ -0,0 +1,154 @@
+package crosscheck
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"198/models"
+	"198/utils"
+)
+
+// fakeReference answers with the pool's own local quote, scaled by numerator/denominator.
+// onQuote runs before each answer, standing in for events that arrive while the on-chain call is in flight.
+type fakeReference struct {
+	numerator, denominator int64
+	onQuote                func(pool *models.Pool)
+}
+
+func (r *fakeReference) Supports(dex string) bool {
+	return dex == "UniswapV2"
+}
+
+func (r *fakeReference) QuoteExactInput(ctx context.Context, pool *models.Pool, amountIn *models.Amount, tokenOut *models.Token, blockNumber uint64) (*models.Amount, error) {
+	if r.onQuote != nil {
+		r.onQuote(pool)
+	}
+	local, err := pool.Quoter.AmountOut(amountIn, tokenOut)
+	if err != nil {
+		return nil, err
+	}
+	raw := new(big.Int).Mul(local.Raw, big.NewInt(r.numerator))
+	return models.NewAmount(tokenOut, raw.Quo(raw, big.NewInt(r.denominator))), nil
+}
+
+func newTestPools(t *testing.T) *models.PoolList {
+	token0 := &models.Token{Symbol: "USDC", Address: "0x0000000000000000000000000000000000000001", Decimals: 6}
+	token1 := &models.Token{Symbol: "WETH", Address: "0x0000000000000000000000000000000000000002", Decimals: 18}
+	pool := &models.Pool{Address: "0x00000000000000000000000000000000000000aa", DEX: "UniswapV2", Fee: big.NewInt(3000), Token0: token0, Token1: token1}
+	pools, err := models.NewPoolListFromSlice([]*models.Pool{pool})
+	if err != nil {
+		t.Fatal(err)
+	}
+	updatePool(t, pools, pool, 100)
+	return pools
+}
+
+// updatePool publishes new reserves for pool, replacing it in pools
+func updatePool(t *testing.T, pools *models.PoolList, pool *models.Pool, blockNumber uint64) {
+	reserve0 := new(big.Int).Mul(big.NewInt(2_000_000), models.OneToken(pool.Token0).Raw)
+	reserve1 := new(big.Int).Mul(big.NewInt(1_000), models.OneToken(pool.Token1).Raw)
+	quoter := utils.ConstantProductQuoter{Token0: pool.Token0, Token1: pool.Token1, Reserve0: reserve0, Reserve1: reserve1, Fee: pool.Fee}
+	if err := pools.UpdatePoolAmountOutsByKey(pool.Key(), blockNumber, nil, nil, nil, reserve0, reserve1, quoter); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestChecker(t *testing.T, reference Reference) *Checker {
+	checker, err := NewChecker(reference, filepath.Join(t.TempDir(), "crosscheck.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { checker.Close() })
+	return checker
+}
+
+func TestRoundWithinTolerance(t *testing.T) {
+	pools := newTestPools(t)
+	checker := newTestChecker(t, &fakeReference{numerator: 1, denominator: 1})
+
+	samples := checker.Round(context.Background(), pools, 4, 0.0001)
+	if len(samples) != 4 {
+		t.Fatalf("got %v samples, want 4", len(samples))
+	}
+	for _, sample := range samples {
+		if sample.Flagged || sample.Divergence != 0 {
+			t.Errorf("sample of %v flagged with divergence %v", sample.AmountIn, sample.Divergence)
+		}
+	}
+	stats := checker.Stats()
+	if len(stats) != 1 || stats[0].Samples != 4 || stats[0].Flagged != 0 || stats[0].Failures != 0 {
+		t.Errorf("got stats %+v, want 4 unflagged samples", stats)
+	}
+}
+
+func TestRoundFlagsDivergence(t *testing.T) {
+	pools := newTestPools(t)
+	// The reference pays 1% less than the local quote
+	checker := newTestChecker(t, &fakeReference{numerator: 99, denominator: 100})
+
+	samples := checker.Round(context.Background(), pools, 3, 0.0001)
+	if len(samples) != 3 {
+		t.Fatalf("got %v samples, want 3", len(samples))
+	}
+	for _, sample := range samples {
+		if !sample.Flagged || sample.Divergence < 0.0100 || sample.Divergence > 0.0102 {
+			t.Errorf("sample of %v: flagged %v with divergence %v, want about 1%% flagged", sample.AmountIn, sample.Flagged, sample.Divergence)
+		}
+	}
+	stats := checker.Stats()
+	if len(stats) != 1 || stats[0].Flagged != 3 || stats[0].MaxDivergence < 0.0100 {
+		t.Errorf("got stats %+v, want 3 flagged samples", stats)
+	}
+}
+
+func TestRoundDropsReplacedPool(t *testing.T) {
+	pools := newTestPools(t)
+	// A swap lands while the reference is queried: local and reference quotes no longer share a state
+	reference := &fakeReference{numerator: 1, denominator: 1}
+	reference.onQuote = func(pool *models.Pool) {
+		updatePool(t, pools, pool, pool.BlockNumber+1)
+	}
+	checker := newTestChecker(t, reference)
+
+	if samples := checker.Round(context.Background(), pools, 3, 0.0001); len(samples) != 0 {
+		t.Fatalf("got %v samples, want the replaced pool's samples dropped", len(samples))
+	}
+	for _, stats := range checker.Stats() {
+		if stats.Samples != 0 || stats.Flagged != 0 {
+			t.Errorf("got stats %+v, want no samples recorded", stats)
+		}
+	}
+}
+
+func TestRoundSkippedWhileRunning(t *testing.T) {
+	pools := newTestPools(t)
+	reference := &fakeReference{numerator: 1, denominator: 1}
+	checker := newTestChecker(t, reference)
+
+	// A second round starting while the first waits on the reference returns at once
+	overlapped := false
+	var overlapping []Sample
+	reference.onQuote = func(pool *models.Pool) {
+		if !overlapped {
+			overlapped = true
+			overlapping = checker.Round(context.Background(), pools, 4, 0.0001)
+		}
+	}
+	if samples := checker.Round(context.Background(), pools, 4, 0.0001); len(samples) != 4 {
+		t.Fatalf("got %v samples, want 4", len(samples))
+	}
+	if len(overlapping) != 0 {
+		t.Errorf("the overlapping round took %v samples, want none", len(overlapping))
+	}
+	if stats := checker.Stats(); len(stats) != 1 || stats[0].Samples != 4 {
+		t.Errorf("stats %+v, want 4 samples of one pool", stats)
+	}
+
+	// Once done, the next round runs
+	reference.onQuote = nil
+	if samples := checker.Round(context.Background(), pools, 2, 0.0001); len(samples) != 2 {
+		t.Errorf("got %v samples after the first round, want 2", len(samples))
+	}
+}
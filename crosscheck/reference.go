@@ -0,0 +1,93 @@
+package crosscheck
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"198/config"
+	"198/dex/quickswapv3"
+	"198/dex/uniswapv3"
+	"198/models"
+)
+
+// Reference quotes an exact-input swap through one pool as of the end of a block.
+// ContractReference asks the DEXes' Quoter contracts; any stand-in with the same answers can replace it.
+type Reference interface {
+	Supports(dex string) bool
+	QuoteExactInput(ctx context.Context, pool *models.Pool, amountIn *models.Amount, tokenOut *models.Token, blockNumber uint64) (*models.Amount, error)
+}
+
+var ErrUnsupportedDEX = errors.New("no quoter contract for the DEX")
+
+// ContractReference calls QuoterV2 for Uniswap V3 forks and the Algebra Quoter for Quickswap V3.
+type ContractReference struct {
+	caller  bind.ContractCaller
+	quoters map[string]common.Address // DEX -> Quoter contract
+}
+
+// NewContractReference uses the Quoter of every deployment that has one.
+func NewContractReference(caller bind.ContractCaller, dexes map[string]config.DEXDeployment) *ContractReference {
+	quoters := make(map[string]common.Address)
+	for name, deployment := range dexes {
+		if deployment.Quoter != "" {
+			quoters[name] = common.HexToAddress(deployment.Quoter)
+		}
+	}
+	return &ContractReference{caller: caller, quoters: quoters}
+}
+
+func (r *ContractReference) Supports(dex string) bool {
+	_, ok := r.quoters[dex]
+	return ok && (dex == "UniswapV3" || dex == "SushiswapV3" || dex == "QuickswapV3")
+}
+
+// QuoteExactInput simulates the quoter's (non-view) quoteExactInputSingle with eth_call at blockNumber.
+func (r *ContractReference) QuoteExactInput(ctx context.Context, pool *models.Pool, amountIn *models.Amount, tokenOut *models.Token, blockNumber uint64) (*models.Amount, error) {
+	if !r.Supports(pool.DEX) {
+		return nil, ErrUnsupportedDEX
+	}
+	address := r.quoters[pool.DEX]
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(blockNumber)}
+	tokenIn, tokenOutAddress := common.HexToAddress(amountIn.Token.Address), common.HexToAddress(tokenOut.Address)
+
+	var out []interface{}
+	if pool.DEX == "QuickswapV3" {
+		// The Algebra quoter reads the pool's current dynamic fee itself
+		quoter, err := quickswapv3.NewQuoterCaller(address, r.caller)
+		if err != nil {
+			return nil, err
+		}
+		raw := &quickswapv3.QuoterCallerRaw{Contract: quoter}
+		if err := raw.Call(opts, &out, "quoteExactInputSingle", tokenIn, tokenOutAddress, amountIn.Raw, big.NewInt(0)); err != nil {
+			return nil, err
+		}
+	} else {
+		quoter, err := uniswapv3.NewQuoterV2Caller(address, r.caller)
+		if err != nil {
+			return nil, err
+		}
+		raw := &uniswapv3.QuoterV2CallerRaw{Contract: quoter}
+		params := uniswapv3.IQuoterV2QuoteExactInputSingleParams{
+			TokenIn:           tokenIn,
+			TokenOut:          tokenOutAddress,
+			AmountIn:          amountIn.Raw,
+			Fee:               pool.Fee,
+			SqrtPriceLimitX96: big.NewInt(0),
+		}
+		if err := raw.Call(opts, &out, "quoteExactInputSingle", params); err != nil {
+			return nil, err
+		}
+	}
+	if len(out) == 0 {
+		return nil, errors.New("empty quoter response")
+	}
+	amountOut, ok := out[0].(*big.Int)
+	if !ok {
+		return nil, errors.New("unexpected quoter response")
+	}
+	return models.NewAmount(tokenOut, amountOut), nil
+}
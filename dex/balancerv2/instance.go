@@ -0,0 +1,354 @@
+package balancerv2
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"198/erc20"
+	"198/models"
+	"198/utils"
+)
+
+// VaultAddress is the Balancer V2 Vault, deployed at the same address on every chain
+const VaultAddress = "0xBA12222222228d8Ba445958a75a0704d566BF2C8"
+
+type Balancerv2Instance struct {
+	DEXSymbol string
+}
+
+func NewBalancerV2Instance(symbol ...string) Balancerv2Instance {
+	instance := Balancerv2Instance{
+		DEXSymbol: "BalancerV2", // default value
+	}
+	if len(symbol) > 0 && symbol[0] != "" {
+		instance.DEXSymbol = symbol[0]
+	}
+	return instance
+}
+
+// WatchPairSwaps follows one token pair (edge) of a Balancer V2 weighted or stable pool.
+// Pool.Address is the pool contract; balances live in the Vault and are tracked through its Swap and PoolBalanceChanged events.
+// A failed subscription is replaced and the state fetched again.
+func (b Balancerv2Instance) WatchPairSwaps(ctx context.Context, ethClient *ethclient.Client, pool *models.Pool, eventBus *models.Bus) {
+	utils.Resubscribe(ctx, fmt.Sprintf("[%v] [%v]", b.DEXSymbol, pool.Address), func() (bool, error) {
+		return b.watchPool(ctx, ethClient, pool, eventBus)
+	})
+}
+
+// watchPool follows one pair of Vault and pool subscriptions until one fails, reporting whether any event was delivered
+func (b Balancerv2Instance) watchPool(ctx context.Context, ethClient *ethclient.Client, pool *models.Pool, eventBus *models.Bus) (bool, error) {
+	DEXSymbol := b.DEXSymbol
+
+	// Specify the pool and Vault contract addresses
+	poolAddress := common.HexToAddress(pool.Address)
+	vaultAddress := common.HexToAddress(VaultAddress)
+
+	// Create instances of the pool and Vault contracts
+	poolContract, err := NewBalancerPool(poolAddress, ethClient)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v pool contract: %v", utils.ErrUnwatchable, DEXSymbol, err)
+	}
+	vaultContract, err := NewVault(vaultAddress, ethClient)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v Vault contract: %v", utils.ErrUnwatchable, DEXSymbol, err)
+	}
+
+	poolId, err := poolContract.GetPoolId(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return false, fmt.Errorf("fetching pool id: %w", err)
+	}
+
+	// Bootstrap balances, weights/amplification and swap fee
+	state, err := fetchState(ethClient, vaultContract, poolContract, poolId, nil)
+	if err != nil {
+		return false, fmt.Errorf("fetching pool state: %w", err)
+	}
+	i, j := -1, -1
+	for k, token := range state.Tokens {
+		if token == common.HexToAddress(pool.Token0.Address) {
+			i = k
+		}
+		if token == common.HexToAddress(pool.Token1.Address) {
+			j = k
+		}
+	}
+	if i < 0 || j < 0 {
+		return false, fmt.Errorf("%w: pool does not hold both %v and %v", utils.ErrUnwatchable, pool.Token0.Symbol, pool.Token1.Symbol)
+	}
+
+	// Vault events for this pool id, in log order
+	vaultABI, err := VaultMetaData.GetAbi()
+	if err != nil {
+		return false, fmt.Errorf("%w: %v Vault ABI: %v", utils.ErrUnwatchable, DEXSymbol, err)
+	}
+	swapTopic := vaultABI.Events["Swap"].ID
+	balanceChangedTopic := vaultABI.Events["PoolBalanceChanged"].ID
+
+	vaultLogChan := make(chan types.Log)
+	vaultSubscription, err := ethClient.SubscribeFilterLogs(ctx, ethereum.FilterQuery{
+		Addresses: []common.Address{vaultAddress},
+		Topics:    [][]common.Hash{{swapTopic, balanceChangedTopic}, {common.Hash(poolId)}},
+	}, vaultLogChan)
+	if err != nil {
+		return false, fmt.Errorf("vault: %w", err)
+	}
+	defer vaultSubscription.Unsubscribe()
+
+	// Pool events changing the swap parameters
+	poolLogChan := make(chan types.Log)
+	poolSubscription, err := ethClient.SubscribeFilterLogs(ctx, ethereum.FilterQuery{
+		Addresses: []common.Address{poolAddress},
+	}, poolLogChan)
+	if err != nil {
+		return false, fmt.Errorf("pool: %w", err)
+	}
+	defer poolSubscription.Unsubscribe()
+	log.Printf("[%v] Subscribed to Vault Swap/PoolBalanceChanged events (%v/%v) (pool: %v) (fee: %v)", DEXSymbol, pool.Token0.Symbol, pool.Token1.Symbol, poolAddress, state.SwapFee)
+
+	poolABI, err := BalancerPoolMetaData.GetAbi()
+	if err != nil {
+		return false, fmt.Errorf("%w: %v pool ABI: %v", utils.ErrUnwatchable, DEXSymbol, err)
+	}
+	feeChangedTopic := poolABI.Events["SwapFeePercentageChanged"].ID
+	parameterTopics := map[common.Hash]bool{
+		feeChangedTopic:                       true,
+		poolABI.Events["AmpUpdateStarted"].ID: true,
+		poolABI.Events["AmpUpdateStopped"].ID: true,
+	}
+
+	// Quote one whole token in each direction
+	unitToken0 := models.OneToken(pool.Token0).Raw
+	unitToken1 := models.OneToken(pool.Token1).Raw
+
+	// Handle incoming Vault and pool events
+	received := false
+	for {
+		select {
+		case vLog := <-poolLogChan:
+			received = true
+			if vLog.Removed || len(vLog.Topics) == 0 || !parameterTopics[vLog.Topics[0]] {
+				continue
+			}
+			if err := refreshParameters(poolContract, state, new(big.Int).SetUint64(vLog.BlockNumber)); err != nil {
+				log.Printf("Failed to refresh %v pool parameters %v: %v", DEXSymbol, poolAddress, err)
+				continue
+			}
+			if vLog.Topics[0] == feeChangedTopic {
+				eventBus.FeeChanges.Publish(models.FeeChangeEvent{
+					DEXSymbol:   DEXSymbol,
+					PoolAddress: pool.Address,
+					PoolKey:     pool.Key(),
+					BlockNumber: vLog.BlockNumber,
+					Fee:         new(big.Int).Quo(state.SwapFee, big.NewInt(1e12)), // 1e18 based -> hundredths of a bip
+				})
+			}
+		case vLog := <-vaultLogChan:
+			received = true
+			if vLog.Removed {
+				continue
+			}
+
+			// Apply the event to the local balances
+			var swapEvent *VaultSwap
+			if vLog.Topics[0] == swapTopic {
+				swapEvent, err = vaultContract.ParseSwap(vLog)
+				if err != nil {
+					log.Printf("Failed to parse Swap event: %v", err)
+					continue
+				}
+				state.ApplySwap(swapEvent.TokenIn, swapEvent.TokenOut, swapEvent.AmountIn, swapEvent.AmountOut)
+			} else {
+				changeEvent, err := vaultContract.ParsePoolBalanceChanged(vLog)
+				if err != nil {
+					log.Printf("Failed to parse PoolBalanceChanged event: %v", err)
+					continue
+				}
+				state.ApplyBalanceChange(changeEvent.Tokens, changeEvent.Deltas, changeEvent.ProtocolFeeAmounts)
+				publishBalanceChange(eventBus, DEXSymbol, pool, vLog.BlockNumber, changeEvent.Tokens, changeEvent.Deltas)
+			}
+
+			// Amplification moves every block while it is ramping
+			if state.AmpUpdating {
+				if err := refreshParameters(poolContract, state, new(big.Int).SetUint64(vLog.BlockNumber)); err != nil {
+					log.Printf("Failed to refresh %v pool parameters %v: %v", DEXSymbol, poolAddress, err)
+				}
+			}
+
+			// -- event latency --
+
+			// Fetch the block header using the BlockNumber from the event
+			blockHeader, err := ethClient.HeaderByNumber(ctx, big.NewInt(int64(vLog.BlockNumber)))
+			if err != nil {
+				log.Printf("Failed to fetch block header: %v", err)
+				continue
+			}
+
+			blockTimestamp := time.Unix(int64(blockHeader.Time), 0)
+			currentTime := time.Now()
+			latency := currentTime.Sub(blockTimestamp)
+
+			// -- event latency --
+
+			// Weighted/stable math output (fee included) for one token in each direction
+			amountOut, err := state.AmountOut(i, j, unitToken0)
+			if err != nil {
+				log.Printf("Failed to quote %v pool %v: %v", DEXSymbol, poolAddress, err)
+				continue
+			}
+			backwardsAmountOut, err := state.AmountOut(j, i, unitToken1)
+			if err != nil {
+				log.Printf("Failed to quote %v pool %v: %v", DEXSymbol, poolAddress, err)
+				continue
+			}
+
+			// Parsed objet
+			eventData := models.EventData{
+				DEXSymbol:               DEXSymbol,
+				PoolAddress:             pool.Address,
+				PoolKey:                 pool.Key(),
+				BlockNumber:             vLog.BlockNumber,
+				Latency:                 latency,
+				Fee:                     new(big.Int).Quo(state.SwapFee, big.NewInt(1e12)), // 1e18 based -> hundredths of a bip
+				Token0Symbol:            pool.Token0.Symbol,
+				Token1Symbol:            pool.Token1.Symbol,
+				Token0ToToken1AmountOut: models.NewAmount(pool.Token1, amountOut),
+				Token1ToToken0AmountOut: models.NewAmount(pool.Token0, backwardsAmountOut),
+				Quoter:                  poolQuoter{state: state.Clone(), i: i, j: j, token0: pool.Token0, token1: pool.Token1},
+			}
+			eventData.SetLog(vLog)
+			if swapEvent != nil {
+				// The Vault Swap event does not name the trader
+				eventData.Amount0 = swapAmount(common.HexToAddress(pool.Token0.Address), swapEvent)
+				eventData.Amount1 = swapAmount(common.HexToAddress(pool.Token1.Address), swapEvent)
+			}
+
+			// Publish the structured data on the event bus
+			eventBus.Swaps.Publish(eventData)
+		case <-ctx.Done():
+			// Pool removed from the configuration
+			return received, ctx.Err()
+		case err := <-vaultSubscription.Err():
+			return received, fmt.Errorf("vault: %w", err)
+		case err := <-poolSubscription.Err():
+			return received, fmt.Errorf("pool: %w", err)
+		}
+	}
+}
+
+// swapAmount is the amount of token moved by a Vault swap, positive into the pool (nil if token was not traded)
+func swapAmount(token common.Address, swapEvent *VaultSwap) *big.Int {
+	switch token {
+	case swapEvent.TokenIn:
+		return swapEvent.AmountIn
+	case swapEvent.TokenOut:
+		return new(big.Int).Neg(swapEvent.AmountOut)
+	}
+	return nil
+}
+
+// publishBalanceChange publishes a join (Mint) or exit (Burn) with the deltas of the pool edge's tokens
+func publishBalanceChange(eventBus *models.Bus, DEXSymbol string, pool *models.Pool, blockNumber uint64, tokens []common.Address, deltas []*big.Int) {
+	liquidityEvent := models.LiquidityEvent{
+		DEXSymbol:   DEXSymbol,
+		PoolAddress: pool.Address,
+		PoolKey:     pool.Key(),
+		BlockNumber: blockNumber,
+		Amount0:     new(big.Int),
+		Amount1:     new(big.Int),
+	}
+	join := false
+	for n, token := range tokens {
+		if deltas[n].Sign() > 0 {
+			join = true
+		}
+		switch token {
+		case common.HexToAddress(pool.Token0.Address):
+			liquidityEvent.Amount0 = new(big.Int).Abs(deltas[n])
+		case common.HexToAddress(pool.Token1.Address):
+			liquidityEvent.Amount1 = new(big.Int).Abs(deltas[n])
+		}
+	}
+
+	// Joins only add tokens and exits only remove them
+	if join {
+		eventBus.Mints.Publish(liquidityEvent)
+	} else {
+		eventBus.Burns.Publish(liquidityEvent)
+	}
+}
+
+// fetchState reads the Vault balances and the pool's swap parameters at the given block (nil for latest)
+func fetchState(ethClient *ethclient.Client, vaultContract *Vault, poolContract *BalancerPool, poolId [32]byte, blockNumber *big.Int) (*PoolState, error) {
+	opts := &bind.CallOpts{Context: context.Background(), BlockNumber: blockNumber}
+
+	poolTokens, err := vaultContract.GetPoolTokens(opts, poolId)
+	if err != nil {
+		return nil, err
+	}
+	state := &PoolState{
+		Tokens:   poolTokens.Tokens,
+		Balances: poolTokens.Balances,
+		BPTIndex: -1,
+	}
+	poolAddress := common.BytesToAddress(poolId[:20]) // the pool id starts with the pool address
+	for k, token := range state.Tokens {
+		if token == poolAddress {
+			state.BPTIndex = k
+		}
+	}
+
+	// Older pools do not expose their scaling factors, derive them from the token decimals
+	state.ScalingFactors, err = poolContract.GetScalingFactors(opts)
+	if err != nil {
+		state.ScalingFactors = make([]*big.Int, len(state.Tokens))
+		for k, token := range state.Tokens {
+			tokenContract, err := erc20.NewErc20(token, ethClient)
+			if err != nil {
+				return nil, err
+			}
+			decimals, err := tokenContract.Decimals(opts)
+			if err != nil {
+				return nil, err
+			}
+			state.ScalingFactors[k] = new(big.Int).Exp(big.NewInt(10), big.NewInt(36-int64(decimals)), nil)
+		}
+	}
+
+	if err := refreshParameters(poolContract, state, blockNumber); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// refreshParameters re-reads the swap fee and weights or amplification of the pool
+func refreshParameters(poolContract *BalancerPool, state *PoolState, blockNumber *big.Int) error {
+	opts := &bind.CallOpts{Context: context.Background(), BlockNumber: blockNumber}
+
+	swapFee, err := poolContract.GetSwapFeePercentage(opts)
+	if err != nil {
+		return err
+	}
+	state.SwapFee = swapFee
+
+	// Weighted pools expose normalized weights, stable pools an amplification parameter
+	if weights, err := poolContract.GetNormalizedWeights(opts); err == nil {
+		state.Weights = weights
+		return nil
+	}
+	amp, err := poolContract.GetAmplificationParameter(opts)
+	if err != nil {
+		return errUnknownPoolFormat
+	}
+	state.Amp = amp.Value
+	state.AmpUpdating = amp.IsUpdating
+	return nil
+}
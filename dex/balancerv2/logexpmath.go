@@ -0,0 +1,180 @@
+package balancerv2
+
+import (
+	"errors"
+	"math/big"
+)
+
+// Constants mirrored from the Balancer V2 LogExpMath library
+var (
+	one20                 = new(big.Int).Exp(big.NewInt(10), big.NewInt(20), nil)         // ONE_20
+	one36                 = new(big.Int).Exp(big.NewInt(10), big.NewInt(36), nil)         // ONE_36
+	maxNaturalExponent    = new(big.Int).Mul(big.NewInt(130), one)                        // MAX_NATURAL_EXPONENT
+	minNaturalExponent    = new(big.Int).Mul(big.NewInt(-41), one)                        // MIN_NATURAL_EXPONENT
+	ln36LowerBound        = big.NewInt(1e18 - 1e17)                                       // LN_36_LOWER_BOUND
+	ln36UpperBound        = big.NewInt(1e18 + 1e17)                                       // LN_36_UPPER_BOUND
+	mildExponentBound     = new(big.Int).Quo(new(big.Int).Lsh(big.NewInt(1), 254), one20) // MILD_EXPONENT_BOUND
+	errPowXOutOfBounds    = errors.New("balancer: pow base out of bounds")
+	errPowYOutOfBounds    = errors.New("balancer: pow exponent out of bounds")
+	errPowProductBounds   = errors.New("balancer: pow product out of bounds")
+	errExpInvalidExponent = errors.New("balancer: exp exponent out of bounds")
+)
+
+// The x_n are powers of two and the a_n are e^(x_n): x0 and x1 in 18 decimals with a0 and a1 without decimals,
+// the rest in 20 decimals
+var (
+	x0 = bigFromString("128000000000000000000")
+	a0 = bigFromString("38877084059945950922200000000000000000000000000000000000")
+	x1 = bigFromString("64000000000000000000")
+	a1 = bigFromString("6235149080811616882910000000")
+	xn = []*big.Int{bigFromString("3200000000000000000000"), bigFromString("1600000000000000000000"), bigFromString("800000000000000000000"), bigFromString("400000000000000000000"), bigFromString("200000000000000000000"), bigFromString("100000000000000000000"), bigFromString("50000000000000000000"), bigFromString("25000000000000000000"), bigFromString("12500000000000000000"), bigFromString("6250000000000000000")}
+	an = []*big.Int{bigFromString("7896296018268069516100000000000000"), bigFromString("888611052050787263676000000"), bigFromString("298095798704172827474000"), bigFromString("5459815003314423907810"), bigFromString("738905609893065022723"), bigFromString("271828182845904523536"), bigFromString("164872127070012814685"), bigFromString("128402541668774148407"), bigFromString("113314845306682631683"), bigFromString("106449445891785942956")}
+)
+
+func bigFromString(s string) *big.Int {
+	value, _ := new(big.Int).SetString(s, 10)
+	return value
+}
+
+// logExpPow is LogExpMath.pow: x^y with both in 18 decimals, through exp(y * ln(x))
+func logExpPow(x, y *big.Int) (*big.Int, error) {
+	if y.Sign() == 0 {
+		return new(big.Int).Set(one), nil
+	}
+	if x.Sign() == 0 {
+		return new(big.Int), nil
+	}
+	if x.BitLen() > 255 {
+		return nil, errPowXOutOfBounds
+	}
+	if y.Cmp(mildExponentBound) >= 0 {
+		return nil, errPowYOutOfBounds
+	}
+
+	var logxTimesY *big.Int
+	if ln36LowerBound.Cmp(x) < 0 && x.Cmp(ln36UpperBound) < 0 {
+		// Close to one, ln is computed with 36 decimals and the extra precision carried into the product
+		lnX := ln36(x)
+		logxTimesY = new(big.Int).Mul(new(big.Int).Quo(lnX, one), y)
+		fraction := new(big.Int).Mul(new(big.Int).Rem(lnX, one), y)
+		logxTimesY.Add(logxTimesY, fraction.Quo(fraction, one))
+	} else {
+		logxTimesY = new(big.Int).Mul(ln(x), y)
+	}
+	logxTimesY.Quo(logxTimesY, one)
+
+	if logxTimesY.Cmp(minNaturalExponent) < 0 || logxTimesY.Cmp(maxNaturalExponent) > 0 {
+		return nil, errPowProductBounds
+	}
+	return exp(logxTimesY)
+}
+
+// exp is LogExpMath.exp: e^x with x in 18 decimals
+func exp(x *big.Int) (*big.Int, error) {
+	if x.Cmp(minNaturalExponent) < 0 || x.Cmp(maxNaturalExponent) > 0 {
+		return nil, errExpInvalidExponent
+	}
+	if x.Sign() < 0 {
+		inverse, err := exp(new(big.Int).Neg(x))
+		if err != nil {
+			return nil, err
+		}
+		result := new(big.Int).Mul(one, one)
+		return result.Quo(result, inverse), nil
+	}
+
+	x = new(big.Int).Set(x)
+	firstAN := big.NewInt(1)
+	if x.Cmp(x0) >= 0 {
+		x.Sub(x, x0)
+		firstAN = a0
+	} else if x.Cmp(x1) >= 0 {
+		x.Sub(x, x1)
+		firstAN = a1
+	}
+
+	// 20 decimals from here on
+	x.Mul(x, big.NewInt(100))
+	product := new(big.Int).Set(one20)
+	for k := 0; k < 8; k++ { // x2 to x9, the smaller terms are left to the series
+		if x.Cmp(xn[k]) >= 0 {
+			x.Sub(x, xn[k])
+			product.Mul(product, an[k]).Quo(product, one20)
+		}
+	}
+
+	// Taylor series of e^x up to the 12th term
+	seriesSum := new(big.Int).Add(one20, x)
+	term := new(big.Int).Set(x)
+	for n := int64(2); n <= 12; n++ {
+		term.Mul(term, x).Quo(term, one20).Quo(term, big.NewInt(n))
+		seriesSum.Add(seriesSum, term)
+	}
+
+	result := product.Mul(product, seriesSum).Quo(product, one20)
+	result.Mul(result, firstAN)
+	return result.Quo(result, big.NewInt(100)), nil
+}
+
+// ln is LogExpMath._ln: the natural logarithm of a positive a in 18 decimals
+func ln(a *big.Int) *big.Int {
+	if a.Cmp(one) < 0 {
+		inverse := new(big.Int).Mul(one, one)
+		return new(big.Int).Neg(ln(inverse.Quo(inverse, a)))
+	}
+
+	a = new(big.Int).Set(a)
+	sum := new(big.Int)
+	if a.Cmp(new(big.Int).Mul(a0, one)) >= 0 {
+		a.Quo(a, a0)
+		sum.Add(sum, x0)
+	}
+	if a.Cmp(new(big.Int).Mul(a1, one)) >= 0 {
+		a.Quo(a, a1)
+		sum.Add(sum, x1)
+	}
+
+	// 20 decimals from here on
+	sum.Mul(sum, big.NewInt(100))
+	a.Mul(a, big.NewInt(100))
+	for k := range an { // a2 to a11
+		if a.Cmp(an[k]) >= 0 {
+			a.Mul(a, one20).Quo(a, an[k])
+			sum.Add(sum, xn[k])
+		}
+	}
+
+	// ln(a) = 2 * (z + z^3/3 + z^5/5 + ...) with z = (a - 1) / (a + 1)
+	z := new(big.Int).Sub(a, one20)
+	z.Mul(z, one20).Quo(z, new(big.Int).Add(a, one20))
+	zSquared := new(big.Int).Mul(z, z)
+	zSquared.Quo(zSquared, one20)
+
+	num := new(big.Int).Set(z)
+	seriesSum := new(big.Int).Set(num)
+	for n := int64(3); n <= 11; n += 2 {
+		num.Mul(num, zSquared).Quo(num, one20)
+		seriesSum.Add(seriesSum, new(big.Int).Quo(num, big.NewInt(n)))
+	}
+	seriesSum.Mul(seriesSum, big.NewInt(2))
+
+	result := sum.Add(sum, seriesSum)
+	return result.Quo(result, big.NewInt(100))
+}
+
+// ln36 is LogExpMath._ln_36: the natural logarithm of x (18 decimals, close to one) in 36 decimals
+func ln36(x *big.Int) *big.Int {
+	x = new(big.Int).Mul(x, one)
+	z := new(big.Int).Sub(x, one36)
+	z.Mul(z, one36).Quo(z, new(big.Int).Add(x, one36))
+	zSquared := new(big.Int).Mul(z, z)
+	zSquared.Quo(zSquared, one36)
+
+	num := new(big.Int).Set(z)
+	seriesSum := new(big.Int).Set(num)
+	for n := int64(3); n <= 15; n += 2 {
+		num.Mul(num, zSquared).Quo(num, one36)
+		seriesSum.Add(seriesSum, new(big.Int).Quo(num, big.NewInt(n)))
+	}
+	return seriesSum.Mul(seriesSum, big.NewInt(2))
+}
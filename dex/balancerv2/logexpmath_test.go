@@ -0,0 +1,101 @@
+package balancerv2
+
+import (
+	"math/big"
+	"testing"
+)
+
+func bigInt(t *testing.T, s string) *big.Int {
+	t.Helper()
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		t.Fatalf("invalid integer %q", s)
+	}
+	return n
+}
+
+// Reference values are the int256 arithmetic of LogExpMath.sol evaluated step by step; each is within
+// the library's documented error of the exact result (the digits after the point in the comments).
+
+func TestExp(t *testing.T) {
+	vectors := []struct{ x, want string }{
+		{"1000000000000000000", "2718281828459045235"},                                                           // 2718281828459045235.36
+		{"-1000000000000000000", "367879441171442321"},                                                           // 367879441171442321.60
+		{"100000000000000000", "1105170918075647624"},                                                            // 1105170918075647624.81
+		{"65500000000000000000", "27943999487401854681054349119240134121766000000"},                              // a1 branch
+		{"129000000000000000000", "105678871143625881256404495171560308048992000000000000000000000000000000000"}, // a0 branch
+		{"-40000000000000000000", "4"},
+		{"0", "1000000000000000000"},
+	}
+	for _, v := range vectors {
+		got, err := exp(bigInt(t, v.x))
+		if err != nil {
+			t.Errorf("exp(%s): %v", v.x, err)
+			continue
+		}
+		if got.String() != v.want {
+			t.Errorf("exp(%s) = %s, want %s", v.x, got, v.want)
+		}
+	}
+
+	for _, x := range []string{"130000000000000000001", "-41000000000000000001"} {
+		if _, err := exp(bigInt(t, x)); err != errExpInvalidExponent {
+			t.Errorf("exp(%s): got %v, want %v", x, err, errExpInvalidExponent)
+		}
+	}
+}
+
+func TestLn(t *testing.T) {
+	vectors := []struct{ a, want string }{
+		{"2000000000000000000", "693147180559945309"},               // 693147180559945309.42
+		{"500000000000000000", "-693147180559945309"},               // inverted below one
+		{"1000000000000000000000000000000", "27631021115928548208"}, // 27631021115928548208.22
+		{"1000000000000000000", "0"},
+		{"3", "-40347919385224712620"},
+	}
+	for _, v := range vectors {
+		if got := ln(bigInt(t, v.a)); got.String() != v.want {
+			t.Errorf("ln(%s) = %s, want %s", v.a, got, v.want)
+		}
+	}
+
+	// _ln_36 keeps 36 decimals close to one: ln(0.95) = -0.05129329438755053342619614425..., ln(1.05) = 0.04879016416943200307...
+	for _, v := range []struct{ x, want string }{
+		{"950000000000000000", "-51293294387550533426196144149312054"},
+		{"1050000000000000000", "48790164169432003065374404178136230"},
+	} {
+		if got := ln36(bigInt(t, v.x)); got.String() != v.want {
+			t.Errorf("ln36(%s) = %s, want %s", v.x, got, v.want)
+		}
+	}
+}
+
+func TestLogExpPow(t *testing.T) {
+	vectors := []struct{ x, y, want string }{
+		{"2000000000000000000", "500000000000000000", "1414213562373095047"},         // 1414213562373095048.80
+		{"950000000000000000", "2500000000000000000", "879648189619008993"},          // ln36 branch, 879648189619008992.59
+		{"1050000000000000000", "300000000000000000", "1014744695422405258"},         // ln36 branch, 1014744695422405259.06
+		{"1000000000000000", "700000000000000000", "7943282347242815"},               // 7943282347242815.02
+		{"1000000000000000000000", "1500000000000000000", "31622776601683793315519"}, // 31622776601683793319988.94
+		{"0", "1500000000000000000", "0"},
+		{"1000000000000000000000", "0", "1000000000000000000"},
+	}
+	for _, v := range vectors {
+		got, err := logExpPow(bigInt(t, v.x), bigInt(t, v.y))
+		if err != nil {
+			t.Errorf("pow(%s, %s): %v", v.x, v.y, err)
+			continue
+		}
+		if got.String() != v.want {
+			t.Errorf("pow(%s, %s) = %s, want %s", v.x, v.y, got, v.want)
+		}
+	}
+
+	// e^(y ln x) beyond MAX_NATURAL_EXPONENT
+	if _, err := logExpPow(bigInt(t, "1000000000000000000000"), bigInt(t, "20000000000000000000")); err != errPowProductBounds {
+		t.Errorf("pow(1000, 20): got %v, want %v", err, errPowProductBounds)
+	}
+	if _, err := logExpPow(bigInt(t, "2000000000000000000"), mildExponentBound); err != errPowYOutOfBounds {
+		t.Errorf("pow(2, MILD_EXPONENT_BOUND): got %v, want %v", err, errPowYOutOfBounds)
+	}
+}
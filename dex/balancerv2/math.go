@@ -0,0 +1,389 @@
+package balancerv2
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Constants mirrored from the Balancer V2 FixedPoint, WeightedMath and StableMath libraries
+var (
+	one                  = big.NewInt(1e18) // FixedPoint.ONE
+	two                  = big.NewInt(2e18)
+	four                 = big.NewInt(4e18)
+	maxPowRelativeError  = big.NewInt(10000) // FixedPoint.MAX_POW_RELATIVE_ERROR
+	maxInRatio           = big.NewInt(3e17)  // WeightedMath._MAX_IN_RATIO
+	maxOutRatio          = big.NewInt(3e17)  // WeightedMath._MAX_OUT_RATIO
+	ampPrecision         = big.NewInt(1e3)   // StableMath._AMP_PRECISION
+	errMaxInRatio        = errors.New("balancer: amount in exceeds max in ratio")
+	errMaxOutRatio       = errors.New("balancer: amount out exceeds max out ratio")
+	errStableNoConverge  = errors.New("balancer: stable invariant did not converge")
+	errUnknownPoolFormat = errors.New("balancer: pool has neither weights nor amplification")
+)
+
+func mulDown(a, b *big.Int) *big.Int {
+	product := new(big.Int).Mul(a, b)
+	return product.Quo(product, one)
+}
+
+func mulUp(a, b *big.Int) *big.Int {
+	product := new(big.Int).Mul(a, b)
+	if product.Sign() == 0 {
+		return product
+	}
+	product.Sub(product, big.NewInt(1)).Quo(product, one)
+	return product.Add(product, big.NewInt(1))
+}
+
+func divDown(a, b *big.Int) *big.Int {
+	aInflated := new(big.Int).Mul(a, one)
+	return aInflated.Quo(aInflated, b)
+}
+
+func divUp(a, b *big.Int) *big.Int {
+	if a.Sign() == 0 {
+		return new(big.Int)
+	}
+	aInflated := new(big.Int).Mul(a, one)
+	aInflated.Sub(aInflated, big.NewInt(1)).Quo(aInflated, b)
+	return aInflated.Add(aInflated, big.NewInt(1))
+}
+
+// rawDivUp is Math.divUp (no fixed point scaling)
+func rawDivUp(a, b *big.Int) *big.Int {
+	if a.Sign() == 0 {
+		return new(big.Int)
+	}
+	q := new(big.Int).Sub(a, big.NewInt(1))
+	q.Quo(q, b)
+	return q.Add(q, big.NewInt(1))
+}
+
+func complement(x *big.Int) *big.Int {
+	if x.Cmp(one) >= 0 {
+		return new(big.Int)
+	}
+	return new(big.Int).Sub(one, x)
+}
+
+// powUp is FixedPoint.powUp: exact for the common exponents (1, 2, 4), LogExpMath.pow rounded up by its maximum relative error otherwise
+func powUp(x, y *big.Int) (*big.Int, error) {
+	switch {
+	case y.Cmp(one) == 0:
+		return new(big.Int).Set(x), nil
+	case y.Cmp(two) == 0:
+		return mulUp(x, x), nil
+	case y.Cmp(four) == 0:
+		square := mulUp(x, x)
+		return mulUp(square, square), nil
+	}
+
+	raw, err := logExpPow(x, y)
+	if err != nil {
+		return nil, err
+	}
+	maxError := mulUp(raw, maxPowRelativeError)
+	maxError.Add(maxError, big.NewInt(1))
+	return raw.Add(raw, maxError), nil
+}
+
+// weightedOutGivenIn is WeightedMath._calcOutGivenIn (all values upscaled to 18 decimals)
+func weightedOutGivenIn(balanceIn, weightIn, balanceOut, weightOut, amountIn *big.Int) (*big.Int, error) {
+	if amountIn.Cmp(mulDown(balanceIn, maxInRatio)) > 0 {
+		return nil, errMaxInRatio
+	}
+
+	denominator := new(big.Int).Add(balanceIn, amountIn)
+	base := divUp(balanceIn, denominator)
+	exponent := divDown(weightIn, weightOut)
+	power, err := powUp(base, exponent)
+	if err != nil {
+		return nil, err
+	}
+
+	return mulDown(balanceOut, complement(power)), nil
+}
+
+// weightedInGivenOut is WeightedMath._calcInGivenOut (all values upscaled to 18 decimals)
+func weightedInGivenOut(balanceIn, weightIn, balanceOut, weightOut, amountOut *big.Int) (*big.Int, error) {
+	if amountOut.Cmp(mulDown(balanceOut, maxOutRatio)) > 0 {
+		return nil, errMaxOutRatio
+	}
+
+	base := divUp(balanceOut, new(big.Int).Sub(balanceOut, amountOut))
+	exponent := divUp(weightOut, weightIn)
+	power, err := powUp(base, exponent)
+	if err != nil {
+		return nil, err
+	}
+	ratio := new(big.Int).Sub(power, one)
+
+	return mulUp(balanceIn, ratio), nil
+}
+
+// stableInvariant is StableMath._calculateInvariant
+func stableInvariant(amp *big.Int, balances []*big.Int) (*big.Int, error) {
+	numTokens := big.NewInt(int64(len(balances)))
+
+	sum := new(big.Int)
+	for _, balance := range balances {
+		sum.Add(sum, balance)
+	}
+	if sum.Sign() == 0 {
+		return sum, nil
+	}
+
+	invariant := new(big.Int).Set(sum)
+	ampTimesTotal := new(big.Int).Mul(amp, numTokens)
+	for i := 0; i < 255; i++ {
+		dP := new(big.Int).Set(invariant)
+		for _, balance := range balances {
+			dP.Mul(dP, invariant).Quo(dP, new(big.Int).Mul(balance, numTokens))
+		}
+		prevInvariant := invariant
+
+		numerator := new(big.Int).Mul(ampTimesTotal, sum)
+		numerator.Quo(numerator, ampPrecision)
+		numerator.Add(numerator, new(big.Int).Mul(dP, numTokens))
+		numerator.Mul(numerator, invariant)
+
+		denominator := new(big.Int).Sub(ampTimesTotal, ampPrecision)
+		denominator.Mul(denominator, invariant).Quo(denominator, ampPrecision)
+		denominator.Add(denominator, new(big.Int).Mul(new(big.Int).Add(numTokens, big.NewInt(1)), dP))
+
+		invariant = numerator.Quo(numerator, denominator)
+		if new(big.Int).Sub(invariant, prevInvariant).CmpAbs(big.NewInt(1)) <= 0 {
+			return invariant, nil
+		}
+	}
+	return nil, errStableNoConverge
+}
+
+// stableBalanceGivenInvariant is StableMath._getTokenBalanceGivenInvariantAndAllOtherBalances
+func stableBalanceGivenInvariant(amp *big.Int, balances []*big.Int, invariant *big.Int, tokenIndex int) (*big.Int, error) {
+	numTokens := big.NewInt(int64(len(balances)))
+	ampTimesTotal := new(big.Int).Mul(amp, numTokens)
+
+	sum := new(big.Int).Set(balances[0])
+	pD := new(big.Int).Mul(balances[0], numTokens)
+	for j := 1; j < len(balances); j++ {
+		pD.Mul(pD, balances[j]).Mul(pD, numTokens).Quo(pD, invariant)
+		sum.Add(sum, balances[j])
+	}
+	sum.Sub(sum, balances[tokenIndex])
+
+	inv2 := new(big.Int).Mul(invariant, invariant)
+	c := rawDivUp(inv2, new(big.Int).Mul(ampTimesTotal, pD))
+	c.Mul(c, ampPrecision).Mul(c, balances[tokenIndex])
+	b := new(big.Int).Quo(invariant, ampTimesTotal)
+	b.Mul(b, ampPrecision).Add(b, sum)
+
+	tokenBalance := rawDivUp(new(big.Int).Add(inv2, c), new(big.Int).Add(invariant, b))
+	for i := 0; i < 255; i++ {
+		prevTokenBalance := tokenBalance
+
+		numerator := new(big.Int).Mul(tokenBalance, tokenBalance)
+		numerator.Add(numerator, c)
+		denominator := new(big.Int).Lsh(tokenBalance, 1)
+		denominator.Add(denominator, b).Sub(denominator, invariant)
+
+		tokenBalance = rawDivUp(numerator, denominator)
+		if new(big.Int).Sub(tokenBalance, prevTokenBalance).CmpAbs(big.NewInt(1)) <= 0 {
+			return tokenBalance, nil
+		}
+	}
+	return nil, errStableNoConverge
+}
+
+// stableOutGivenIn is StableMath._calcOutGivenIn (all values upscaled to 18 decimals)
+func stableOutGivenIn(amp *big.Int, balances []*big.Int, indexIn, indexOut int, amountIn *big.Int) (*big.Int, error) {
+	invariant, err := stableInvariant(amp, balances)
+	if err != nil {
+		return nil, err
+	}
+
+	newBalances := make([]*big.Int, len(balances))
+	copy(newBalances, balances)
+	newBalances[indexIn] = new(big.Int).Add(balances[indexIn], amountIn)
+
+	finalBalanceOut, err := stableBalanceGivenInvariant(amp, newBalances, invariant, indexOut)
+	if err != nil {
+		return nil, err
+	}
+
+	amountOut := new(big.Int).Sub(balances[indexOut], finalBalanceOut)
+	amountOut.Sub(amountOut, big.NewInt(1))
+	if amountOut.Sign() < 0 {
+		return new(big.Int), nil
+	}
+	return amountOut, nil
+}
+
+// stableInGivenOut is StableMath._calcInGivenOut (all values upscaled to 18 decimals)
+func stableInGivenOut(amp *big.Int, balances []*big.Int, indexIn, indexOut int, amountOut *big.Int) (*big.Int, error) {
+	invariant, err := stableInvariant(amp, balances)
+	if err != nil {
+		return nil, err
+	}
+	if amountOut.Cmp(balances[indexOut]) >= 0 {
+		return nil, errors.New("balancer: amount out exceeds the pool balance")
+	}
+
+	newBalances := make([]*big.Int, len(balances))
+	copy(newBalances, balances)
+	newBalances[indexOut] = new(big.Int).Sub(balances[indexOut], amountOut)
+
+	finalBalanceIn, err := stableBalanceGivenInvariant(amp, newBalances, invariant, indexIn)
+	if err != nil {
+		return nil, err
+	}
+
+	amountIn := new(big.Int).Sub(finalBalanceIn, balances[indexIn])
+	return amountIn.Add(amountIn, big.NewInt(1)), nil
+}
+
+// PoolState is the local copy of a Balancer V2 pool: Vault balances plus the pool's own swap parameters.
+// Exactly one of Weights (weighted pools) or Amp (stable pools) is set.
+type PoolState struct {
+	Tokens         []common.Address
+	Balances       []*big.Int // Raw Vault balances, in Tokens order
+	ScalingFactors []*big.Int // 1e18 * 10^(18 - decimals), including rate providers where the pool has them
+	SwapFee        *big.Int   // 1e18 based swap fee percentage
+	Weights        []*big.Int // Normalized weights, 1e18 based
+	Amp            *big.Int   // Amplification parameter, _AMP_PRECISION based
+	AmpUpdating    bool       // Amplification is ramping and must be re-read per block
+	BPTIndex       int        // Index of the pool's own token among Tokens (composable stable pools), -1 if absent
+}
+
+// AmountOut returns the raw amount of token j received for a raw amountIn of token i (indexes into Tokens), as the pool's onSwap would for GIVEN_IN.
+func (s *PoolState) AmountOut(i, j int, amountIn *big.Int) (*big.Int, error) {
+	if i == j || i < 0 || j < 0 || i >= len(s.Tokens) || j >= len(s.Tokens) {
+		return nil, errors.New("balancer: invalid token indexes")
+	}
+
+	// Fees are subtracted before scaling
+	amount := new(big.Int).Sub(amountIn, mulUp(amountIn, s.SwapFee))
+	amount = mulDown(amount, s.ScalingFactors[i])
+
+	var amountOut *big.Int
+	var err error
+	switch {
+	case s.Weights != nil:
+		amountOut, err = weightedOutGivenIn(mulDown(s.Balances[i], s.ScalingFactors[i]), s.Weights[i], mulDown(s.Balances[j], s.ScalingFactors[j]), s.Weights[j], amount)
+	case s.Amp != nil:
+		// Stable math runs over every token except the pool's own BPT
+		var balances []*big.Int
+		indexIn, indexOut := -1, -1
+		for k := range s.Tokens {
+			if k == s.BPTIndex {
+				continue
+			}
+			if k == i {
+				indexIn = len(balances)
+			}
+			if k == j {
+				indexOut = len(balances)
+			}
+			balances = append(balances, mulDown(s.Balances[k], s.ScalingFactors[k]))
+		}
+		if indexIn < 0 || indexOut < 0 {
+			return nil, errors.New("balancer: cannot swap the pool token")
+		}
+		amountOut, err = stableOutGivenIn(s.Amp, balances, indexIn, indexOut, amount)
+	default:
+		return nil, errUnknownPoolFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Downscale, rounding down
+	return divDown(amountOut, s.ScalingFactors[j]), nil
+}
+
+// AmountIn returns the raw amount of token i needed to receive a raw amountOut of token j, as the pool's onSwap would for GIVEN_OUT.
+func (s *PoolState) AmountIn(i, j int, amountOut *big.Int) (*big.Int, error) {
+	if i == j || i < 0 || j < 0 || i >= len(s.Tokens) || j >= len(s.Tokens) {
+		return nil, errors.New("balancer: invalid token indexes")
+	}
+
+	amount := mulDown(amountOut, s.ScalingFactors[j])
+
+	var amountIn *big.Int
+	var err error
+	switch {
+	case s.Weights != nil:
+		amountIn, err = weightedInGivenOut(mulDown(s.Balances[i], s.ScalingFactors[i]), s.Weights[i], mulDown(s.Balances[j], s.ScalingFactors[j]), s.Weights[j], amount)
+	case s.Amp != nil:
+		var balances []*big.Int
+		indexIn, indexOut := -1, -1
+		for k := range s.Tokens {
+			if k == s.BPTIndex {
+				continue
+			}
+			if k == i {
+				indexIn = len(balances)
+			}
+			if k == j {
+				indexOut = len(balances)
+			}
+			balances = append(balances, mulDown(s.Balances[k], s.ScalingFactors[k]))
+		}
+		if indexIn < 0 || indexOut < 0 {
+			return nil, errors.New("balancer: cannot swap the pool token")
+		}
+		amountIn, err = stableInGivenOut(s.Amp, balances, indexIn, indexOut, amount)
+	default:
+		return nil, errUnknownPoolFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Downscale rounding up, then add the fee on top (_addSwapFeeAmount)
+	amountIn = divUp(amountIn, s.ScalingFactors[i])
+	return divUp(amountIn, complement(s.SwapFee)), nil
+}
+
+// Clone returns a deep copy of the state, so that a published copy is unaffected by later events.
+func (s *PoolState) Clone() *PoolState {
+	clone := *s
+	clone.Tokens = append([]common.Address(nil), s.Tokens...)
+	clone.Balances = make([]*big.Int, len(s.Balances))
+	for k, balance := range s.Balances {
+		clone.Balances[k] = new(big.Int).Set(balance)
+	}
+	clone.ScalingFactors = append([]*big.Int(nil), s.ScalingFactors...)
+	clone.Weights = append([]*big.Int(nil), s.Weights...)
+	if s.Weights == nil {
+		clone.Weights = nil
+	}
+	return &clone
+}
+
+// ApplySwap updates the balances after a Vault Swap event
+func (s *PoolState) ApplySwap(tokenIn, tokenOut common.Address, amountIn, amountOut *big.Int) {
+	for k, token := range s.Tokens {
+		if token == tokenIn {
+			s.Balances[k] = new(big.Int).Add(s.Balances[k], amountIn)
+		}
+		if token == tokenOut {
+			s.Balances[k] = new(big.Int).Sub(s.Balances[k], amountOut)
+		}
+	}
+}
+
+// ApplyBalanceChange updates the balances after a Vault PoolBalanceChanged event (joins and exits).
+// Protocol fees charged on the change leave the pool.
+func (s *PoolState) ApplyBalanceChange(tokens []common.Address, deltas, protocolFeeAmounts []*big.Int) {
+	for n, changed := range tokens {
+		for k, token := range s.Tokens {
+			if token != changed {
+				continue
+			}
+			s.Balances[k] = new(big.Int).Add(s.Balances[k], deltas[n])
+			s.Balances[k].Sub(s.Balances[k], protocolFeeAmounts[n])
+		}
+	}
+}
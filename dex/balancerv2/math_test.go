@@ -0,0 +1,86 @@
+package balancerv2
+
+import (
+	"math/big"
+	"testing"
+)
+
+// Reference values are the FixedPoint, WeightedMath and StableMath arithmetic evaluated step by step.
+
+func TestWeightedMath(t *testing.T) {
+	e18 := func(n int64) *big.Int { return new(big.Int).Mul(big.NewInt(n), one) }
+
+	// 80/20 pool, 1000 in against 4000 out
+	out, err := weightedOutGivenIn(e18(1000), big.NewInt(8e17), e18(4000), big.NewInt(2e17), e18(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "156078622068734844000"; out.String() != want {
+		t.Errorf("80/20 out given in = %s, want %s", out, want)
+	}
+	in, err := weightedInGivenOut(e18(1000), big.NewInt(8e17), e18(4000), big.NewInt(2e17), e18(150))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "9601100868690356000"; in.String() != want {
+		t.Errorf("80/20 in given out = %s, want %s", in, want)
+	}
+
+	// Equal weights take the exact powUp path: 2000 * (1 - 1000/1010)
+	out, err = weightedOutGivenIn(e18(1000), big.NewInt(5e17), e18(2000), big.NewInt(5e17), e18(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "19801980198019800000"; out.String() != want {
+		t.Errorf("50/50 out given in = %s, want %s", out, want)
+	}
+
+	if _, err := weightedOutGivenIn(e18(1000), big.NewInt(5e17), e18(2000), big.NewInt(5e17), e18(301)); err != errMaxInRatio {
+		t.Errorf("above _MAX_IN_RATIO: got %v, want %v", err, errMaxInRatio)
+	}
+	if _, err := weightedInGivenOut(e18(1000), big.NewInt(5e17), e18(2000), big.NewInt(5e17), e18(601)); err != errMaxOutRatio {
+		t.Errorf("above _MAX_OUT_RATIO: got %v, want %v", err, errMaxOutRatio)
+	}
+}
+
+func TestStableMath(t *testing.T) {
+	amp := big.NewInt(200 * 1e3)
+	balances := []*big.Int{
+		bigInt(t, "1000000000000000123456789"),
+		bigInt(t, "1200000000000000000000000"),
+		bigInt(t, "900000000000000987654321"),
+	}
+
+	invariant, err := stableInvariant(amp, balances)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "3099888769460455807157836"; invariant.String() != want {
+		t.Errorf("invariant = %s, want %s", invariant, want)
+	}
+
+	out, err := stableOutGivenIn(amp, balances, 0, 2, bigInt(t, "10000000000000000000000"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "9993579688538949524448"; out.String() != want {
+		t.Errorf("out given in = %s, want %s", out, want)
+	}
+	in, err := stableInGivenOut(amp, balances, 1, 0, bigInt(t, "10000000000000000000000"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "10009211936761749985658"; in.String() != want {
+		t.Errorf("in given out = %s, want %s", in, want)
+	}
+
+	// A balanced pool's invariant is the sum of its balances
+	balanced := []*big.Int{bigInt(t, "5000000000000000000000"), bigInt(t, "5000000000000000000000")}
+	if invariant, err := stableInvariant(amp, balanced); err != nil || invariant.String() != "10000000000000000000000" {
+		t.Errorf("balanced invariant = %v (%v), want 10000000000000000000000", invariant, err)
+	}
+
+	if _, err := stableInGivenOut(amp, balances, 1, 0, balances[0]); err == nil {
+		t.Error("amount out of the whole balance: expected an error")
+	}
+}
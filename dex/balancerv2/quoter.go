@@ -0,0 +1,46 @@
+package balancerv2
+
+import (
+	"198/models"
+)
+
+// poolQuoter quotes one pair (tokens i and j) of a Balancer V2 pool at a fixed state.
+type poolQuoter struct {
+	state          *PoolState
+	i, j           int
+	token0, token1 *models.Token
+}
+
+func (q poolQuoter) AmountOut(amountIn *models.Amount, tokenOut *models.Token) (*models.Amount, error) {
+	i, j, err := q.orient(amountIn.Token, tokenOut)
+	if err != nil {
+		return nil, err
+	}
+	amountOut, err := q.state.AmountOut(i, j, amountIn.Raw)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewAmount(tokenOut, amountOut), nil
+}
+
+func (q poolQuoter) AmountIn(amountOut *models.Amount, tokenIn *models.Token) (*models.Amount, error) {
+	i, j, err := q.orient(tokenIn, amountOut.Token)
+	if err != nil {
+		return nil, err
+	}
+	amountIn, err := q.state.AmountIn(i, j, amountOut.Raw)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewAmount(tokenIn, amountIn), nil
+}
+
+func (q poolQuoter) orient(tokenIn, tokenOut *models.Token) (int, int, error) {
+	switch {
+	case models.SameToken(tokenIn, q.token0) && models.SameToken(tokenOut, q.token1):
+		return q.i, q.j, nil
+	case models.SameToken(tokenIn, q.token1) && models.SameToken(tokenOut, q.token0):
+		return q.j, q.i, nil
+	}
+	return 0, 0, models.ErrTokenMismatch
+}
@@ -0,0 +1,341 @@
+package curve
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"198/erc20"
+	"198/models"
+	"198/utils"
+)
+
+// maxCoins bounds the coins(i) discovery loop (StableSwap pools hold at most 8 coins)
+const maxCoins = 8
+
+// ERC20 events emitted by pools that are their own LP token; they do not change balances
+var (
+	transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	approvalTopic = crypto.Keccak256Hash([]byte("Approval(address,address,uint256)"))
+)
+
+type CurveInstance struct {
+	DEXSymbol string
+}
+
+func NewCurveInstance(symbol ...string) CurveInstance {
+	instance := CurveInstance{
+		DEXSymbol: "Curve", // default value
+	}
+	if len(symbol) > 0 && symbol[0] != "" {
+		instance.DEXSymbol = symbol[0]
+	}
+	return instance
+}
+
+// WatchPairSwaps follows one token pair (edge) of a StableSwap pool.
+// Pools with more than two coins are configured once per pair, each edge keeping its own copy of the pool state.
+// A failed subscription is replaced and the state fetched again.
+func (c CurveInstance) WatchPairSwaps(ctx context.Context, ethClient *ethclient.Client, pool *models.Pool, eventBus *models.Bus) {
+	utils.Resubscribe(ctx, fmt.Sprintf("[%v] [%v]", c.DEXSymbol, pool.Address), func() (bool, error) {
+		return c.watchPool(ctx, ethClient, pool, eventBus)
+	})
+}
+
+// watchPool follows one subscription to the pool's events until it fails, reporting whether it delivered any event
+func (c CurveInstance) watchPool(ctx context.Context, ethClient *ethclient.Client, pool *models.Pool, eventBus *models.Bus) (bool, error) {
+	DEXSymbol := c.DEXSymbol
+
+	// Specify the StableSwap pool contract address
+	poolAddress := common.HexToAddress(pool.Address)
+
+	// Create an instance of the pool contract
+	poolContract, err := NewCurve(poolAddress, ethClient)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v contract: %v", utils.ErrUnwatchable, DEXSymbol, err)
+	}
+
+	// Discover the pool coins and the indexes of our token pair
+	var coins []common.Address
+	for k := 0; k < maxCoins; k++ {
+		coin, err := poolContract.Coins(&bind.CallOpts{Context: ctx}, big.NewInt(int64(k)))
+		if err != nil {
+			// coins(k) reverts past the last coin, but a pool always has a first one
+			if k == 0 {
+				return false, fmt.Errorf("fetching coins: %w", err)
+			}
+			break
+		}
+		coins = append(coins, coin)
+	}
+	i, j := -1, -1
+	for k, coin := range coins {
+		if coin == common.HexToAddress(pool.Token0.Address) {
+			i = k
+		}
+		if coin == common.HexToAddress(pool.Token1.Address) {
+			j = k
+		}
+	}
+	if i < 0 || j < 0 {
+		return false, fmt.Errorf("%w: pool does not hold both %v and %v", utils.ErrUnwatchable, pool.Token0.Symbol, pool.Token1.Symbol)
+	}
+
+	// Bootstrap balances, amplification and fees
+	state, err := fetchState(ethClient, poolContract, coins, nil)
+	if err != nil {
+		return false, fmt.Errorf("fetching pool state: %w", err)
+	}
+	var syncedBlock uint64 // events up to this block are already included in state
+
+	// Watch every log of the pool so that balance changes are applied in order
+	logChan := make(chan types.Log)
+	subscription, err := ethClient.SubscribeFilterLogs(ctx, ethereum.FilterQuery{
+		Addresses: []common.Address{poolAddress},
+	}, logChan)
+	if err != nil {
+		return false, err
+	}
+	defer subscription.Unsubscribe()
+	log.Printf("[%v] Subscribed to pool events (%v/%v) (pool: %v) (coins: %v/%v)", DEXSymbol, pool.Token0.Symbol, pool.Token1.Symbol, poolAddress, i, j)
+
+	parsedABI, err := CurveMetaData.GetAbi()
+	if err != nil {
+		return false, fmt.Errorf("%w: %v ABI: %v", utils.ErrUnwatchable, DEXSymbol, err)
+	}
+	events := parsedABI.Events
+
+	// Quote one whole token in each direction
+	unitToken0 := models.OneToken(pool.Token0).Raw
+	unitToken1 := models.OneToken(pool.Token1).Raw
+
+	// Handle incoming pool events
+	received := false
+	for {
+		select {
+		case vLog := <-logChan:
+			received = true
+			if vLog.Removed || len(vLog.Topics) == 0 || vLog.BlockNumber <= syncedBlock {
+				continue
+			}
+			topic := vLog.Topics[0]
+			if topic == transferTopic || topic == approvalTopic {
+				continue
+			}
+
+			// -- event latency --
+
+			// Fetch the block header using the BlockNumber from the event
+			blockHeader, err := ethClient.HeaderByNumber(ctx, big.NewInt(int64(vLog.BlockNumber)))
+			if err != nil {
+				log.Printf("Failed to fetch block header: %v", err)
+				continue
+			}
+
+			blockTimestamp := time.Unix(int64(blockHeader.Time), 0)
+			currentTime := time.Now()
+			latency := currentTime.Sub(blockTimestamp)
+
+			// -- event latency --
+
+			// Apply the event to the local state
+			var exchangeEvent *CurveTokenExchange
+			switch topic {
+			case events["TokenExchange"].ID:
+				exchangeEvent, err = poolContract.ParseTokenExchange(vLog)
+				if err == nil {
+					err = state.ApplyExchange(int(exchangeEvent.SoldId.Int64()), int(exchangeEvent.BoughtId.Int64()), exchangeEvent.TokensSold, exchangeEvent.TokensBought, blockHeader.Time)
+				}
+				if err != nil {
+					log.Printf("Failed to apply TokenExchange event: %v", err)
+					continue
+				}
+			case events["AddLiquidity"].ID:
+				addEvent, err := poolContract.ParseAddLiquidity(vLog)
+				if err != nil {
+					log.Printf("Failed to parse AddLiquidity event: %v", err)
+					continue
+				}
+				state.ApplyAddLiquidity(addEvent.TokenAmounts[:], addEvent.Fees[:])
+				eventBus.Mints.Publish(models.LiquidityEvent{
+					DEXSymbol:   DEXSymbol,
+					PoolAddress: pool.Address,
+					PoolKey:     pool.Key(),
+					BlockNumber: vLog.BlockNumber,
+					Amount0:     addEvent.TokenAmounts[i],
+					Amount1:     addEvent.TokenAmounts[j],
+				})
+			case events["RemoveLiquidity"].ID:
+				removeEvent, err := poolContract.ParseRemoveLiquidity(vLog)
+				if err != nil {
+					log.Printf("Failed to parse RemoveLiquidity event: %v", err)
+					continue
+				}
+				state.ApplyRemoveLiquidity(removeEvent.TokenAmounts[:])
+				eventBus.Burns.Publish(models.LiquidityEvent{
+					DEXSymbol:   DEXSymbol,
+					PoolAddress: pool.Address,
+					PoolKey:     pool.Key(),
+					BlockNumber: vLog.BlockNumber,
+					Amount0:     removeEvent.TokenAmounts[i],
+					Amount1:     removeEvent.TokenAmounts[j],
+				})
+			case events["NewFee"].ID:
+				feeEvent, err := poolContract.ParseNewFee(vLog)
+				if err != nil {
+					log.Printf("Failed to parse NewFee event: %v", err)
+					continue
+				}
+				state.Fee, state.AdminFee = feeEvent.Fee, feeEvent.AdminFee
+				eventBus.FeeChanges.Publish(models.FeeChangeEvent{
+					DEXSymbol:   DEXSymbol,
+					PoolAddress: pool.Address,
+					PoolKey:     pool.Key(),
+					BlockNumber: vLog.BlockNumber,
+					Fee:         feeEvent.Fee,
+				})
+			case events["RampA"].ID:
+				rampEvent, err := poolContract.ParseRampA(vLog)
+				if err != nil {
+					log.Printf("Failed to parse RampA event: %v", err)
+					continue
+				}
+				state.InitialA, state.FutureA = rampEvent.OldA, rampEvent.NewA
+				state.InitialATime, state.FutureATime = rampEvent.InitialTime, rampEvent.FutureTime
+			case events["StopRampA"].ID:
+				stopEvent, err := poolContract.ParseStopRampA(vLog)
+				if err != nil {
+					log.Printf("Failed to parse StopRampA event: %v", err)
+					continue
+				}
+				state.InitialA, state.FutureA = stopEvent.A, stopEvent.A
+				state.InitialATime, state.FutureATime = stopEvent.T, stopEvent.T
+			default:
+				// Events we cannot apply exactly (single-coin / imbalanced withdrawals, other coin counts): re-read the pool at this block
+				refreshed, err := fetchState(ethClient, poolContract, coins, new(big.Int).SetUint64(vLog.BlockNumber))
+				if err != nil {
+					log.Printf("Failed to refresh %v pool state %v: %v", DEXSymbol, poolAddress, err)
+					continue
+				}
+				state = refreshed
+				syncedBlock = vLog.BlockNumber
+			}
+
+			// Exact get_dy output (fee included) for one token in each direction
+			amountOut, err := state.GetDy(i, j, unitToken0, blockHeader.Time)
+			if err != nil {
+				log.Printf("Failed to quote %v pool %v: %v", DEXSymbol, poolAddress, err)
+				continue
+			}
+			backwardsAmountOut, err := state.GetDy(j, i, unitToken1, blockHeader.Time)
+			if err != nil {
+				log.Printf("Failed to quote %v pool %v: %v", DEXSymbol, poolAddress, err)
+				continue
+			}
+
+			// Parsed objet
+			eventData := models.EventData{
+				DEXSymbol:               DEXSymbol,
+				PoolAddress:             pool.Address,
+				PoolKey:                 pool.Key(),
+				BlockNumber:             vLog.BlockNumber,
+				Latency:                 latency,
+				Fee:                     state.Fee,
+				Token0Symbol:            pool.Token0.Symbol,
+				Token1Symbol:            pool.Token1.Symbol,
+				Token0ToToken1AmountOut: models.NewAmount(pool.Token1, amountOut),
+				Token1ToToken0AmountOut: models.NewAmount(pool.Token0, backwardsAmountOut),
+				Quoter:                  stableSwapQuoter{state: state.Clone(), i: i, j: j, token0: pool.Token0, token1: pool.Token1, timestamp: blockHeader.Time},
+			}
+			eventData.SetLog(vLog)
+			if exchangeEvent != nil {
+				eventData.Amount0 = exchangeAmount(i, exchangeEvent)
+				eventData.Amount1 = exchangeAmount(j, exchangeEvent)
+				eventData.Sender, eventData.Recipient = exchangeEvent.Buyer, exchangeEvent.Buyer
+			}
+
+			// Publish the structured data on the event bus
+			eventBus.Swaps.Publish(eventData)
+		case <-ctx.Done():
+			// Pool removed from the configuration
+			return received, ctx.Err()
+		case err := <-subscription.Err():
+			return received, err
+		}
+	}
+}
+
+// exchangeAmount is the amount of coin k moved by an exchange, positive into the pool (nil if coin k was not traded)
+func exchangeAmount(k int, exchangeEvent *CurveTokenExchange) *big.Int {
+	switch int64(k) {
+	case exchangeEvent.SoldId.Int64():
+		return exchangeEvent.TokensSold
+	case exchangeEvent.BoughtId.Int64():
+		return new(big.Int).Neg(exchangeEvent.TokensBought)
+	}
+	return nil
+}
+
+// fetchState reads balances, amplification and fees of a StableSwap pool at the given block (nil for latest)
+func fetchState(ethClient *ethclient.Client, poolContract *Curve, coins []common.Address, blockNumber *big.Int) (*StableSwapState, error) {
+	opts := &bind.CallOpts{Context: context.Background(), BlockNumber: blockNumber}
+
+	state := &StableSwapState{
+		Balances: make([]*big.Int, len(coins)),
+		Rates:    make([]*big.Int, len(coins)),
+	}
+	for k, coin := range coins {
+		balance, err := poolContract.Balances(opts, big.NewInt(int64(k)))
+		if err != nil {
+			return nil, err
+		}
+		state.Balances[k] = balance
+
+		token, err := erc20.NewErc20(coin, ethClient)
+		if err != nil {
+			return nil, err
+		}
+		decimals, err := token.Decimals(opts)
+		if err != nil {
+			return nil, err
+		}
+		state.Rates[k] = new(big.Int).Exp(big.NewInt(10), big.NewInt(36-int64(decimals)), nil)
+	}
+
+	var err error
+	if state.Fee, err = poolContract.Fee(opts); err != nil {
+		return nil, err
+	}
+	if state.AdminFee, err = poolContract.AdminFee(opts); err != nil {
+		return nil, err
+	}
+
+	// Pools exposing A_precise store amplification multiplied by A_PRECISION = 100
+	state.APrecision = big.NewInt(1)
+	if _, err := poolContract.APrecise(opts); err == nil {
+		state.APrecision = big.NewInt(100)
+	}
+	if state.InitialA, err = poolContract.InitialA(opts); err != nil {
+		return nil, err
+	}
+	if state.FutureA, err = poolContract.FutureA(opts); err != nil {
+		return nil, err
+	}
+	if state.InitialATime, err = poolContract.InitialATime(opts); err != nil {
+		return nil, err
+	}
+	if state.FutureATime, err = poolContract.FutureATime(opts); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
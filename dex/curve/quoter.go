@@ -0,0 +1,49 @@
+package curve
+
+import (
+	"math/big"
+
+	"198/models"
+)
+
+// stableSwapQuoter quotes one pair (coins i and j) of a StableSwap pool at a fixed state and timestamp.
+type stableSwapQuoter struct {
+	state          *StableSwapState
+	i, j           int
+	token0, token1 *models.Token
+	timestamp      uint64 // Block timestamp, for A while it ramps
+}
+
+func (q stableSwapQuoter) AmountOut(amountIn *models.Amount, tokenOut *models.Token) (*models.Amount, error) {
+	i, j, err := q.orient(amountIn.Token, tokenOut)
+	if err != nil {
+		return nil, err
+	}
+	dy, err := q.state.GetDy(i, j, amountIn.Raw, q.timestamp)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewAmount(tokenOut, dy), nil
+}
+
+func (q stableSwapQuoter) AmountIn(amountOut *models.Amount, tokenIn *models.Token) (*models.Amount, error) {
+	i, j, err := q.orient(tokenIn, amountOut.Token)
+	if err != nil {
+		return nil, err
+	}
+	dx, err := q.state.GetDx(i, j, amountOut.Raw, q.timestamp)
+	if err != nil {
+		return nil, models.ErrInsufficientLiquidity
+	}
+	return models.NewAmount(tokenIn, new(big.Int).Set(dx)), nil
+}
+
+func (q stableSwapQuoter) orient(tokenIn, tokenOut *models.Token) (int, int, error) {
+	switch {
+	case models.SameToken(tokenIn, q.token0) && models.SameToken(tokenOut, q.token1):
+		return q.i, q.j, nil
+	case models.SameToken(tokenIn, q.token1) && models.SameToken(tokenOut, q.token0):
+		return q.j, q.i, nil
+	}
+	return 0, 0, models.ErrTokenMismatch
+}
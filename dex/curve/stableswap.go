@@ -0,0 +1,266 @@
+package curve
+
+import (
+	"errors"
+	"math/big"
+)
+
+// Constants mirrored from the StableSwap contracts
+var (
+	precision      = big.NewInt(1e18) // PRECISION
+	feeDenominator = big.NewInt(1e10) // FEE_DENOMINATOR
+)
+
+// StableSwapState is the local copy of a StableSwap pool needed to reproduce get_dy exactly.
+type StableSwapState struct {
+	Balances []*big.Int // Raw coin balances (balances(i))
+	Rates    []*big.Int // 10^(36 - decimals) per coin, i.e. RATES in the contracts
+
+	Fee      *big.Int // Swap fee, FEE_DENOMINATOR based
+	AdminFee *big.Int // Share of the fee kept by the admin, FEE_DENOMINATOR based
+
+	APrecision   *big.Int // A_PRECISION (1 for older pools, 100 for pools exposing A_precise)
+	InitialA     *big.Int // Amplification at the start of a ramp, A_PRECISION based
+	FutureA      *big.Int // Amplification at the end of a ramp, A_PRECISION based
+	InitialATime *big.Int
+	FutureATime  *big.Int
+}
+
+// A returns the amplification coefficient (A_PRECISION based) at the given timestamp, following the contracts' _A().
+func (s *StableSwapState) A(timestamp uint64) *big.Int {
+	t := new(big.Int).SetUint64(timestamp)
+	if t.Cmp(s.FutureATime) >= 0 || s.FutureATime.Cmp(s.InitialATime) <= 0 {
+		return new(big.Int).Set(s.FutureA)
+	}
+
+	elapsed := new(big.Int).Sub(t, s.InitialATime)
+	duration := new(big.Int).Sub(s.FutureATime, s.InitialATime)
+	if s.FutureA.Cmp(s.InitialA) > 0 {
+		delta := new(big.Int).Sub(s.FutureA, s.InitialA)
+		delta.Mul(delta, elapsed).Quo(delta, duration)
+		return delta.Add(s.InitialA, delta)
+	}
+	delta := new(big.Int).Sub(s.InitialA, s.FutureA)
+	delta.Mul(delta, elapsed).Quo(delta, duration)
+	return delta.Sub(s.InitialA, delta)
+}
+
+// xp returns the balances normalized to 18 decimals
+func (s *StableSwapState) xp() []*big.Int {
+	xp := make([]*big.Int, len(s.Balances))
+	for i, balance := range s.Balances {
+		xp[i] = new(big.Int).Mul(balance, s.Rates[i])
+		xp[i].Quo(xp[i], precision)
+	}
+	return xp
+}
+
+// getD solves the StableSwap invariant for D by Newton's method (get_D)
+func (s *StableSwapState) getD(xp []*big.Int, amp *big.Int) (*big.Int, error) {
+	nCoins := big.NewInt(int64(len(xp)))
+
+	sum := new(big.Int)
+	for _, x := range xp {
+		sum.Add(sum, x)
+	}
+	if sum.Sign() == 0 {
+		return sum, nil
+	}
+
+	d := new(big.Int).Set(sum)
+	ann := new(big.Int).Mul(amp, nCoins)
+	for i := 0; i < 255; i++ {
+		dP := new(big.Int).Set(d)
+		for _, x := range xp {
+			if x.Sign() == 0 {
+				return nil, errors.New("stableswap: empty balance")
+			}
+			dP.Mul(dP, d).Quo(dP, new(big.Int).Mul(x, nCoins))
+		}
+		dPrev := d
+
+		// D = (Ann * S / A_PRECISION + D_P * N) * D / ((Ann - A_PRECISION) * D / A_PRECISION + (N + 1) * D_P)
+		numerator := new(big.Int).Mul(ann, sum)
+		numerator.Quo(numerator, s.APrecision)
+		numerator.Add(numerator, new(big.Int).Mul(dP, nCoins))
+		numerator.Mul(numerator, d)
+
+		denominator := new(big.Int).Sub(ann, s.APrecision)
+		denominator.Mul(denominator, d).Quo(denominator, s.APrecision)
+		denominator.Add(denominator, new(big.Int).Mul(new(big.Int).Add(nCoins, big.NewInt(1)), dP))
+
+		d = numerator.Quo(numerator, denominator)
+		if new(big.Int).Sub(d, dPrev).CmpAbs(big.NewInt(1)) <= 0 {
+			return d, nil
+		}
+	}
+	return nil, errors.New("stableswap: D did not converge")
+}
+
+// getY computes the new balance of coin j once coin i has balance x, keeping D constant (get_y)
+func (s *StableSwapState) getY(i, j int, x *big.Int, xp []*big.Int, amp *big.Int) (*big.Int, error) {
+	nCoins := big.NewInt(int64(len(xp)))
+
+	d, err := s.getD(xp, amp)
+	if err != nil {
+		return nil, err
+	}
+	ann := new(big.Int).Mul(amp, nCoins)
+
+	c := new(big.Int).Set(d)
+	sum := new(big.Int)
+	for k := range xp {
+		var xk *big.Int
+		if k == i {
+			xk = x
+		} else if k != j {
+			xk = xp[k]
+		} else {
+			continue
+		}
+		sum.Add(sum, xk)
+		c.Mul(c, d).Quo(c, new(big.Int).Mul(xk, nCoins))
+	}
+	c.Mul(c, d).Mul(c, s.APrecision).Quo(c, new(big.Int).Mul(ann, nCoins))
+	b := new(big.Int).Mul(d, s.APrecision)
+	b.Quo(b, ann).Add(b, sum)
+
+	y := new(big.Int).Set(d)
+	for k := 0; k < 255; k++ {
+		yPrev := y
+
+		// y = (y*y + c) / (2*y + b - D)
+		numerator := new(big.Int).Mul(y, y)
+		numerator.Add(numerator, c)
+		denominator := new(big.Int).Lsh(y, 1)
+		denominator.Add(denominator, b).Sub(denominator, d)
+
+		y = numerator.Quo(numerator, denominator)
+		if new(big.Int).Sub(y, yPrev).CmpAbs(big.NewInt(1)) <= 0 {
+			return y, nil
+		}
+	}
+	return nil, errors.New("stableswap: y did not converge")
+}
+
+// GetDy returns the amount of coin j received for dx of coin i at the given timestamp, matching the contracts' get_dy.
+func (s *StableSwapState) GetDy(i, j int, dx *big.Int, timestamp uint64) (*big.Int, error) {
+	dy, _, err := s.exchange(i, j, dx, timestamp)
+	return dy, err
+}
+
+// exchange returns both the amount out (get_dy) and the admin fee taken from coin j (as in exchange())
+func (s *StableSwapState) exchange(i, j int, dx *big.Int, timestamp uint64) (*big.Int, *big.Int, error) {
+	if i == j || i < 0 || j < 0 || i >= len(s.Balances) || j >= len(s.Balances) {
+		return nil, nil, errors.New("stableswap: invalid coin indexes")
+	}
+
+	xp := s.xp()
+	x := new(big.Int).Mul(dx, s.Rates[i])
+	x.Quo(x, precision).Add(x, xp[i])
+
+	y, err := s.getY(i, j, x, xp, s.A(timestamp))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// dy = (xp[j] - y - 1) * PRECISION / rates[j]
+	dy := new(big.Int).Sub(xp[j], y)
+	dy.Sub(dy, big.NewInt(1))
+	if dy.Sign() <= 0 {
+		return big.NewInt(0), big.NewInt(0), nil
+	}
+	dyFee := new(big.Int).Mul(dy, s.Fee)
+	dyFee.Quo(dyFee, feeDenominator)
+	adminFee := new(big.Int).Mul(dyFee, s.AdminFee)
+	adminFee.Quo(adminFee, feeDenominator).Mul(adminFee, precision).Quo(adminFee, s.Rates[j])
+
+	dy.Mul(dy, precision).Quo(dy, s.Rates[j])
+	fee := new(big.Int).Mul(dy, s.Fee)
+	fee.Quo(fee, feeDenominator)
+	return dy.Sub(dy, fee), adminFee, nil
+}
+
+// ApplyExchange updates the balances after a TokenExchange event.
+// Coin i receives tokensSold, coin j loses tokensBought plus the admin share of the fee.
+func (s *StableSwapState) ApplyExchange(i, j int, tokensSold, tokensBought *big.Int, timestamp uint64) error {
+	_, adminFee, err := s.exchange(i, j, tokensSold, timestamp)
+	if err != nil {
+		return err
+	}
+	s.Balances[i] = new(big.Int).Add(s.Balances[i], tokensSold)
+	s.Balances[j] = new(big.Int).Sub(s.Balances[j], tokensBought)
+	s.Balances[j].Sub(s.Balances[j], adminFee)
+	return nil
+}
+
+// ApplyAddLiquidity updates the balances after an AddLiquidity event.
+// The admin share of the imbalance fees is not kept in the pool balances.
+func (s *StableSwapState) ApplyAddLiquidity(amounts, fees []*big.Int) {
+	for k := range s.Balances {
+		adminFee := new(big.Int).Mul(fees[k], s.AdminFee)
+		adminFee.Quo(adminFee, feeDenominator)
+		s.Balances[k] = new(big.Int).Add(s.Balances[k], amounts[k])
+		s.Balances[k].Sub(s.Balances[k], adminFee)
+	}
+}
+
+// ApplyRemoveLiquidity updates the balances after a (balanced) RemoveLiquidity event.
+func (s *StableSwapState) ApplyRemoveLiquidity(amounts []*big.Int) {
+	for k := range s.Balances {
+		s.Balances[k] = new(big.Int).Sub(s.Balances[k], amounts[k])
+	}
+}
+
+// GetDx returns the amount of coin i needed to receive at least dy of coin j, as get_dx on pools exposing it:
+// the balance of i is solved for xp[j] minus dy grossed up by the fee, then rounded up until GetDy pays dy.
+func (s *StableSwapState) GetDx(i, j int, dy *big.Int, timestamp uint64) (*big.Int, error) {
+	if i == j || i < 0 || j < 0 || i >= len(s.Balances) || j >= len(s.Balances) {
+		return nil, errors.New("stableswap: invalid coin indexes")
+	}
+	if dy.Sign() <= 0 || dy.Cmp(s.Balances[j]) >= 0 {
+		return nil, errors.New("stableswap: dy exceeds the pool balance")
+	}
+
+	// y = xp[j] - (dy * rates[j] / PRECISION + 1) * FEE_DENOMINATOR / (FEE_DENOMINATOR - fee)
+	xp := s.xp()
+	y := new(big.Int).Mul(dy, s.Rates[j])
+	y.Quo(y, precision).Add(y, big.NewInt(1))
+	y.Mul(y, feeDenominator).Quo(y, new(big.Int).Sub(feeDenominator, s.Fee))
+	y.Sub(xp[j], y)
+	if y.Sign() <= 0 {
+		return nil, errors.New("stableswap: dy exceeds the pool balance")
+	}
+
+	x, err := s.getY(j, i, y, xp, s.A(timestamp))
+	if err != nil {
+		return nil, err
+	}
+	// dx = (x - xp[i]) * PRECISION / rates[i] + 1
+	dx := new(big.Int).Sub(x, xp[i])
+	dx.Mul(dx, precision).Quo(dx, s.Rates[i]).Add(dx, big.NewInt(1))
+
+	// The formula can be a few units short of what get_dy pays for, never over
+	for k := 0; k < 16; k++ {
+		got, err := s.GetDy(i, j, dx, timestamp)
+		if err != nil {
+			return nil, err
+		}
+		if got.Cmp(dy) >= 0 {
+			return dx, nil
+		}
+		dx.Add(dx, big.NewInt(1))
+	}
+	return nil, errors.New("stableswap: dx did not converge")
+}
+
+// Clone returns a deep copy of the state, so that a published copy is unaffected by later events.
+func (s *StableSwapState) Clone() *StableSwapState {
+	clone := *s
+	clone.Balances = make([]*big.Int, len(s.Balances))
+	for k, balance := range s.Balances {
+		clone.Balances[k] = new(big.Int).Set(balance)
+	}
+	clone.Rates = append([]*big.Int(nil), s.Rates...)
+	return &clone
+}
@@ -0,0 +1,169 @@
+package curve
+
+import (
+	"math/big"
+	"testing"
+)
+
+func bigInt(t *testing.T, s string) *big.Int {
+	t.Helper()
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		t.Fatalf("invalid integer %q", s)
+	}
+	return n
+}
+
+// threePool is a DAI/USDC/USDT pool shaped like 3pool (A = 2000, 0.01% fee, half of it to the admin).
+// aPrecision 1 is the original contract, 100 the A_precise layout of later pools.
+func threePool(t *testing.T, aPrecision int64) *StableSwapState {
+	a := big.NewInt(2000 * aPrecision)
+	return &StableSwapState{
+		Balances:     []*big.Int{bigInt(t, "170000000123456789012345678"), bigInt(t, "185000000654321"), bigInt(t, "95000000111111")},
+		Rates:        []*big.Int{bigInt(t, "1000000000000000000"), bigInt(t, "1000000000000000000000000000000"), bigInt(t, "1000000000000000000000000000000")},
+		Fee:          big.NewInt(1000000),
+		AdminFee:     big.NewInt(5000000000),
+		APrecision:   big.NewInt(aPrecision),
+		InitialA:     a,
+		FutureA:      a,
+		InitialATime: big.NewInt(0),
+		FutureATime:  big.NewInt(0),
+	}
+}
+
+func TestStableSwapGetD(t *testing.T) {
+	// Reference values from the integer arithmetic of StableSwap3Pool.vy get_D
+	for _, aPrecision := range []int64{1, 100} {
+		s := threePool(t, aPrecision)
+		d, err := s.getD(s.xp(), s.A(0))
+		if err != nil {
+			t.Fatalf("A_PRECISION %d: %v", aPrecision, err)
+		}
+		if want := "449990285641839181317607970"; d.String() != want {
+			t.Errorf("A_PRECISION %d: D = %s, want %s", aPrecision, d, want)
+		}
+	}
+
+	// A balanced pool sits on the constant-sum line: D is the sum of the balances
+	s := threePool(t, 1)
+	xp := []*big.Int{bigInt(t, "1000000000000000000000000"), bigInt(t, "1000000000000000000000000"), bigInt(t, "1000000000000000000000000")}
+	d, err := s.getD(xp, s.A(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "3000000000000000000000000"; d.String() != want {
+		t.Errorf("balanced D = %s, want %s", d, want)
+	}
+}
+
+func TestStableSwapGetDy(t *testing.T) {
+	// Reference values from the integer arithmetic of StableSwap3Pool.vy get_dy (get_y and the fee included)
+	vectors := []struct {
+		name   string
+		i, j   int
+		dx, dy string
+	}{
+		{"1000 DAI for USDC", 0, 1, "1000000000000000000000", "999940378"},
+		{"1M USDC for USDT", 1, 2, "1000000000000", "999459529124"},
+		{"50M USDT for DAI", 2, 0, "50000000000000", "50001081155782589795011195"},
+		{"1 unit of USDC for DAI", 1, 0, "1", "999859620205"},
+	}
+	for _, aPrecision := range []int64{1, 100} {
+		for _, v := range vectors {
+			dy, err := threePool(t, aPrecision).GetDy(v.i, v.j, bigInt(t, v.dx), 0)
+			if err != nil {
+				t.Errorf("%s (A_PRECISION %d): %v", v.name, aPrecision, err)
+				continue
+			}
+			if dy.String() != v.dy {
+				t.Errorf("%s (A_PRECISION %d): dy = %s, want %s", v.name, aPrecision, dy, v.dy)
+			}
+		}
+	}
+
+	if _, err := threePool(t, 1).GetDy(1, 1, big.NewInt(1), 0); err == nil {
+		t.Error("same coin: expected an error")
+	}
+	if _, err := threePool(t, 1).GetDy(0, 3, big.NewInt(1), 0); err == nil {
+		t.Error("coin out of range: expected an error")
+	}
+}
+
+func TestStableSwapGetDx(t *testing.T) {
+	s := threePool(t, 100)
+	for _, v := range []struct {
+		i, j   int
+		dx, dy string
+	}{
+		{0, 1, "1000000000000000000000", "999940378"},
+		{1, 2, "1000000000000", "999459529124"},
+		{2, 0, "50000000000000", "50001081155782589795011195"},
+	} {
+		dy := bigInt(t, v.dy)
+		dx, err := s.GetDx(v.i, v.j, dy, 0)
+		if err != nil {
+			t.Fatalf("%d -> %d: %v", v.i, v.j, err)
+		}
+		got, err := s.GetDy(v.i, v.j, dx, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Cmp(dy) < 0 {
+			t.Errorf("%d -> %d: GetDy(GetDx(%s)) = %s", v.i, v.j, dy, got)
+		}
+		// At most the rounding unit above the input the dy vector was computed for
+		if limit := new(big.Int).Add(bigInt(t, v.dx), big.NewInt(1)); dx.Cmp(limit) > 0 {
+			t.Errorf("%d -> %d: dx = %s, want at most %s", v.i, v.j, dx, limit)
+		}
+	}
+
+	if _, err := s.GetDx(0, 1, s.Balances[1], 0); err == nil {
+		t.Error("dy of the whole balance: expected an error")
+	}
+}
+
+func TestStableSwapA(t *testing.T) {
+	s := &StableSwapState{
+		InitialA:     big.NewInt(1000),
+		FutureA:      big.NewInt(2000),
+		InitialATime: big.NewInt(1000),
+		FutureATime:  big.NewInt(2000),
+	}
+	for _, v := range []struct {
+		timestamp uint64
+		want      int64
+	}{{1000, 1000}, {1250, 1250}, {1999, 1999}, {2000, 2000}, {3000, 2000}} {
+		if a := s.A(v.timestamp); a.Int64() != v.want {
+			t.Errorf("ramp up at %d: A = %s, want %d", v.timestamp, a, v.want)
+		}
+	}
+
+	s.InitialA, s.FutureA = big.NewInt(2000), big.NewInt(1000)
+	for _, v := range []struct {
+		timestamp uint64
+		want      int64
+	}{{1000, 2000}, {1250, 1750}, {1333, 1667}, {2000, 1000}} {
+		if a := s.A(v.timestamp); a.Int64() != v.want {
+			t.Errorf("ramp down at %d: A = %s, want %d", v.timestamp, a, v.want)
+		}
+	}
+}
+
+func TestStableSwapApplyExchange(t *testing.T) {
+	s := threePool(t, 1)
+	dx := bigInt(t, "1000000000000000000000")
+	dy := bigInt(t, "999940378")
+	if err := s.ApplyExchange(0, 1, dx, dy, 0); err != nil {
+		t.Fatal(err)
+	}
+	// USDC loses dy plus the admin half of the 0.1 USDC fee (50002, from exchange() in StableSwap3Pool.vy)
+	if want := "170001000123456789012345678"; s.Balances[0].String() != want {
+		t.Errorf("DAI balance = %s, want %s", s.Balances[0], want)
+	}
+	if want := "184999000663941"; s.Balances[1].String() != want {
+		t.Errorf("USDC balance = %s, want %s", s.Balances[1], want)
+	}
+	if want := "95000000111111"; s.Balances[2].String() != want {
+		t.Errorf("USDT balance = %s, want %s", s.Balances[2], want)
+	}
+}
@@ -1,8 +1,12 @@
 package dex
 
 import (
+	"198/dex/balancerv2"
+	"198/dex/curve"
 	"198/dex/quickswapv3"
+	"198/dex/uniswapv2"
 	"198/dex/uniswapv3"
+	"198/dex/uniswapv4"
 	"198/models"
 )
 
@@ -11,4 +15,27 @@ var DEXImplementations = map[string]models.DEXInstance{
 	"UniswapV3":   uniswapv3.NewUniswapV3Instance(),
 	"SushiswapV3": uniswapv3.NewUniswapV3Instance("SushiswapV3"), // NOTE: Ensure we are using the correct router in the implementation
 	"QuickswapV3": quickswapv3.NewQuickswapV3Instance(),
+	"UniswapV2":   uniswapv2.NewUniswapV2Instance(),
+	"QuickswapV2": uniswapv2.NewUniswapV2Instance("QuickswapV2"),
+	"SushiswapV2": uniswapv2.NewUniswapV2Instance("SushiswapV2"),
+	"Curve":       curve.NewCurveInstance(),           // NOTE: Multi-asset pools are configured once per token pair (see models.EdgeID)
+	"BalancerV2":  balancerv2.NewBalancerV2Instance(), // NOTE: Multi-asset pools are configured once per token pair (see models.EdgeID)
+	"UniswapV4":   uniswapv4.NewUniswapV4Instance(),   // NOTE: Address is the PoolManager, ID the PoolId (see uniswapv4.PoolID)
 }
+
+// SwapGas is the typical gas used by one swap hop on each DEX, for route gas estimates.
+// Concentrated-liquidity figures exclude tick crossings; V4 and Balancer hops exclude the settlement shared by a whole route.
+var SwapGas = map[string]uint64{
+	"UniswapV3":   110000,
+	"SushiswapV3": 110000,
+	"QuickswapV3": 120000,
+	"UniswapV2":   90000,
+	"QuickswapV2": 90000,
+	"SushiswapV2": 90000,
+	"Curve":       130000,
+	"BalancerV2":  100000,
+	"UniswapV4":   80000,
+}
+
+// TxBaseGas is the gas of a transaction before any swap (intrinsic cost plus router overhead).
+const TxBaseGas = 50000
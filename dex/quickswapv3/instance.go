@@ -2,6 +2,7 @@ package quickswapv3
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"math/big"
 	"time"
@@ -28,11 +29,22 @@ func NewQuickswapV3Instance(symbol ...string) Quickswapv3Instance {
 	return instance
 }
 
-func (u Quickswapv3Instance) WatchPairSwaps(ethClient *ethclient.Client, pool *models.Pool, universalChan chan<- models.EventData) {
+// WatchPairSwaps follows one pool, subscribing again whenever a subscription fails.
+func (u Quickswapv3Instance) WatchPairSwaps(ctx context.Context, ethClient *ethclient.Client, pool *models.Pool, eventBus *models.Bus) {
+	var fee *big.Int // Dynamic fee as of the last swap, kept across subscriptions to notice changes in between
+	utils.Resubscribe(ctx, fmt.Sprintf("[%v] [%v]", u.DEXSymbol, pool.Address), func() (bool, error) {
+		return u.watchPool(ctx, ethClient, pool, eventBus, &fee)
+	})
+}
+
+// watchPool follows one set of subscriptions to the pool's events until one fails, reporting whether any event was delivered
+func (u Quickswapv3Instance) watchPool(ctx context.Context, ethClient *ethclient.Client, pool *models.Pool, eventBus *models.Bus, lastFee **big.Int) (bool, error) {
 	DEXSymbol := u.DEXSymbol
 
 	// local channel for direct subscription
 	swapEventChan := make(chan *Quickswapv3Swap)
+	mintEventChan := make(chan *Quickswapv3Mint)
+	burnEventChan := make(chan *Quickswapv3Burn)
 
 	// Specify the QuickswapV3 Pool contract address
 	poolAddress := common.HexToAddress(pool.Address)
@@ -40,29 +52,55 @@ func (u Quickswapv3Instance) WatchPairSwaps(ethClient *ethclient.Client, pool *m
 	// Create an instance of the pool contract
 	poolContract, err := NewQuickswapv3(poolAddress, ethClient)
 	if err != nil {
-		log.Fatalf("Failed to instantiate %v contract: %v", DEXSymbol, err)
+		return false, fmt.Errorf("%w: %v contract: %v", utils.ErrUnwatchable, DEXSymbol, err)
 	}
 
 	// Start watching for Swap events
 	subscription, err := poolContract.WatchSwap(&bind.WatchOpts{
-		Context: context.Background(),
+		Context: ctx,
 	}, swapEventChan, nil, nil)
 	if err != nil {
-		log.Fatalf("Failed to subscribe to Swap events: %v", err)
+		return false, err
 	}
 	defer subscription.Unsubscribe()
-	log.Printf("[%v] Subscribed to Swap events (%v/%v) (pool: %v) (fee: %v)", DEXSymbol, pool.Token0.Symbol, pool.Token1.Symbol, poolAddress, pool.Fee)
 
-	// Handle incoming Swap events
+	// Liquidity changes are published for other consumers, the swaps carry the state the quotes need
+	mintSubscription, err := poolContract.WatchMint(&bind.WatchOpts{
+		Context: ctx,
+	}, mintEventChan, nil, nil, nil)
+	if err != nil {
+		return false, err
+	}
+	defer mintSubscription.Unsubscribe()
+	burnSubscription, err := poolContract.WatchBurn(&bind.WatchOpts{
+		Context: ctx,
+	}, burnEventChan, nil, nil, nil)
+	if err != nil {
+		return false, err
+	}
+	defer burnSubscription.Unsubscribe()
+	log.Printf("[%v] Subscribed to Swap/Mint/Burn events (%v/%v) (pool: %v) (fee: %v)", DEXSymbol, pool.Token0.Symbol, pool.Token1.Symbol, poolAddress, pool.Fee)
+
+	// Initialized ticks are multiples of the tick spacing, which never changes
+	tickSpacing, err := poolContract.TickSpacing(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return false, fmt.Errorf("fetching tick spacing: %w", err)
+	}
+	// Dynamic fee as of the last swap (pool.Fee is only the configured value), changes are published on FeeChanges
+	fee := *lastFee
+
+	// Handle incoming Swap/Mint/Burn events
+	received := false
 	for {
 		select {
 		case swapEvent := <-swapEventChan:
-			// Calculate necessary info then redirect to a new universalChan
+			received = true
+			// Calculate necessary info then publish it on the event bus
 
 			// -- event latency --
 
 			// Fetch the block header using the BlockNumber from the swap event
-			blockHeader, err := ethClient.HeaderByNumber(context.Background(), big.NewInt(int64(swapEvent.Raw.BlockNumber)))
+			blockHeader, err := ethClient.HeaderByNumber(ctx, big.NewInt(int64(swapEvent.Raw.BlockNumber)))
 			if err != nil {
 				log.Printf("Failed to fetch block header: %v", err)
 				continue
@@ -74,54 +112,107 @@ func (u Quickswapv3Instance) WatchPairSwaps(ethClient *ethclient.Client, pool *m
 
 			// -- event latency --
 
-			// Calculate the price ratio
-			priceRatio, err := utils.CalculatePriceRatio(swapEvent.Price)
+			// The fee charged and the in-range liquidity bounds, read as of the swap's block
+			callOpts := &bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(swapEvent.Raw.BlockNumber)}
+			globalState, err := poolContract.GlobalState(callOpts)
 			if err != nil {
-				log.Printf("Error calculating price ratio: %v", err)
+				log.Printf("Failed to fetch the global state: %v", err)
+				continue
+			}
+			if fee != nil && fee.Uint64() != uint64(globalState.Fee) {
+				eventBus.FeeChanges.Publish(models.FeeChangeEvent{
+					DEXSymbol:   DEXSymbol,
+					PoolAddress: pool.Address,
+					PoolKey:     pool.Key(),
+					BlockNumber: swapEvent.Raw.BlockNumber,
+					Fee:         big.NewInt(int64(globalState.Fee)),
+				})
+			}
+			fee = big.NewInt(int64(globalState.Fee))
+			*lastFee = fee
+			sqrtPriceLower, sqrtPriceUpper, err := utils.InitializedTickRange(func(wordPos int16) (*big.Int, error) {
+				return poolContract.TickTable(callOpts, wordPos)
+			}, int(swapEvent.Tick.Int64()), int(tickSpacing.Int64()))
+			if err != nil {
+				log.Printf("Failed to fetch the tick table: %v", err)
 				continue
 			}
 
-			// Output the exchange rate
-			//adjustedPrice := utils.AdjustForTokenDecimals(priceRatio, pool.Token0.Decimals, pool.Token1.Decimals)
-			//adjustedPrice := priceRatio
-			adjustedPrice := utils.AdjustForTokenDecimals(priceRatio, pool.Token0.Decimals, pool.Token1.Decimals)
-
-			// Calculate price for the other direction (Token0 per Token1)
-			token0PerToken1 := new(big.Float).Quo(big.NewFloat(1), adjustedPrice)
-
-			// Consider exchange fees and gas cost (assumed to be unitless)
-			inputAmount := big.NewFloat(1.0) // Example input amount (1 token0)
-
-			// Calculate fee amount
-			feePercentage := utils.FeeToFeePercentage(pool.Fee)
-			feeAmount := new(big.Float).Mul(inputAmount, big.NewFloat(feePercentage))
-
-			// Net input amount after fee
-			netInputAmount := new(big.Float).Sub(inputAmount, feeAmount)
-
-			// Calculate output amount using net input amount and adjusted price
-			amountOut := new(big.Float).Mul(netInputAmount, adjustedPrice)
+			// Exact output of one whole token at the current price, net of the fee and rounded down like the pool
+			amountOut, err := utils.QuoteAtSqrtPrice(swapEvent.Price, models.OneToken(pool.Token0).Raw, fee, true)
+			if err != nil {
+				log.Printf("Error quoting %v -> %v: %v", pool.Token0.Symbol, pool.Token1.Symbol, err)
+				continue
+			}
 
 			// Token1 -> Token0
-			backwardsAmountsOut := new(big.Float).Mul(netInputAmount, token0PerToken1)
+			backwardsAmountOut, err := utils.QuoteAtSqrtPrice(swapEvent.Price, models.OneToken(pool.Token1).Raw, fee, false)
+			if err != nil {
+				log.Printf("Error quoting %v -> %v: %v", pool.Token1.Symbol, pool.Token0.Symbol, err)
+				continue
+			}
 
 			// Parsed objet
 			eventData := models.EventData{
 				DEXSymbol:               DEXSymbol,
 				PoolAddress:             pool.Address,
+				PoolKey:                 pool.Key(),
 				BlockNumber:             swapEvent.Raw.BlockNumber,
 				Latency:                 latency,
-				Fee:                     pool.Fee,
+				Fee:                     fee,
 				Token0Symbol:            pool.Token0.Symbol,
 				Token1Symbol:            pool.Token1.Symbol,
-				Token0ToToken1AmountOut: amountOut,
-				Token1ToToken0AmountOut: backwardsAmountsOut,
+				Token0ToToken1AmountOut: models.NewAmount(pool.Token1, amountOut),
+				Token1ToToken0AmountOut: models.NewAmount(pool.Token0, backwardsAmountOut),
+				Amount0:                 swapEvent.Amount0,
+				Amount1:                 swapEvent.Amount1,
+				SqrtPriceX96:            swapEvent.Price,
+				Liquidity:               swapEvent.Liquidity,
+				Tick:                    swapEvent.Tick,
+				Sender:                  swapEvent.Sender,
+				Recipient:               swapEvent.Recipient,
 			}
-
-			// Send the structured data to the universal channel
-			universalChan <- eventData
+			eventData.Reserve0, eventData.Reserve1 = utils.VirtualReserves(swapEvent.Price, swapEvent.Liquidity)
+			eventData.Quoter = utils.ConcentratedLiquidityQuoter{Token0: pool.Token0, Token1: pool.Token1, SqrtPriceX96: swapEvent.Price, Liquidity: swapEvent.Liquidity, SqrtPriceLowerX96: sqrtPriceLower, SqrtPriceUpperX96: sqrtPriceUpper, Fee: fee, Algebra: true}
+			eventData.SetLog(swapEvent.Raw)
+
+			// Publish the structured data on the event bus
+			eventBus.Swaps.Publish(eventData)
+		case mintEvent := <-mintEventChan:
+			received = true
+			eventBus.Mints.Publish(models.LiquidityEvent{
+				DEXSymbol:   DEXSymbol,
+				PoolAddress: pool.Address,
+				PoolKey:     pool.Key(),
+				BlockNumber: mintEvent.Raw.BlockNumber,
+				Amount0:     mintEvent.Amount0,
+				Amount1:     mintEvent.Amount1,
+				Liquidity:   mintEvent.LiquidityAmount,
+			})
+		case burnEvent := <-burnEventChan:
+			received = true
+			// Zero burns only poke a position to collect its fees
+			if burnEvent.LiquidityAmount.Sign() == 0 {
+				continue
+			}
+			eventBus.Burns.Publish(models.LiquidityEvent{
+				DEXSymbol:   DEXSymbol,
+				PoolAddress: pool.Address,
+				PoolKey:     pool.Key(),
+				BlockNumber: burnEvent.Raw.BlockNumber,
+				Amount0:     burnEvent.Amount0,
+				Amount1:     burnEvent.Amount1,
+				Liquidity:   burnEvent.LiquidityAmount,
+			})
+		case <-ctx.Done():
+			// Pool removed from the configuration
+			return received, ctx.Err()
+		case err := <-mintSubscription.Err():
+			return received, fmt.Errorf("mint: %w", err)
+		case err := <-burnSubscription.Err():
+			return received, fmt.Errorf("burn: %w", err)
 		case err := <-subscription.Err():
-			log.Printf("ERROR: [%s] [%v] Subscription: %v", DEXSymbol, pool.Address, err)
+			return received, fmt.Errorf("swap: %w", err)
 		}
 	}
 }
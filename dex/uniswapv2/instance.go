@@ -0,0 +1,206 @@
+package uniswapv2
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"198/models"
+	"198/utils"
+)
+
+type Uniswapv2Instance struct {
+	DEXSymbol string
+}
+
+func NewUniswapV2Instance(symbol ...string) Uniswapv2Instance {
+	instance := Uniswapv2Instance{
+		DEXSymbol: "UniswapV2", // default value
+	}
+	if len(symbol) > 0 && symbol[0] != "" {
+		instance.DEXSymbol = symbol[0]
+	}
+	return instance
+}
+
+// WatchPairSwaps follows one pair, subscribing again (and re-reading its reserves) whenever the subscription fails.
+func (u Uniswapv2Instance) WatchPairSwaps(ctx context.Context, ethClient *ethclient.Client, pool *models.Pool, eventBus *models.Bus) {
+	utils.Resubscribe(ctx, fmt.Sprintf("[%v] [%v]", u.DEXSymbol, pool.Address), func() (bool, error) {
+		return u.watchPool(ctx, ethClient, pool, eventBus)
+	})
+}
+
+// watchPool follows one subscription to the pair's events until it fails, reporting whether it delivered any event
+func (u Uniswapv2Instance) watchPool(ctx context.Context, ethClient *ethclient.Client, pool *models.Pool, eventBus *models.Bus) (bool, error) {
+	DEXSymbol := u.DEXSymbol
+
+	// Specify the UniswapV2 Pair contract address
+	poolAddress := common.HexToAddress(pool.Address)
+
+	// Create an instance of the pair contract
+	poolContract, err := NewUniswapv2(poolAddress, ethClient)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v contract: %v", utils.ErrUnwatchable, DEXSymbol, err)
+	}
+
+	// Make sure the configured token order matches the pair (token0 < token1 by address)
+	token0, err := poolContract.Token0(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return false, fmt.Errorf("fetching token0: %w", err)
+	}
+	if token0 != common.HexToAddress(pool.Token0.Address) {
+		return false, fmt.Errorf("%w: pair has token0 %v but %v is configured as Token0", utils.ErrUnwatchable, token0, pool.Token0.Symbol)
+	}
+
+	// Bootstrap reserves, afterwards they are kept up to date by Sync events
+	reserves, err := poolContract.GetReserves(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return false, fmt.Errorf("fetching reserves: %w", err)
+	}
+	reserve0, reserve1 := reserves.Reserve0, reserves.Reserve1
+
+	// Sync, Swap, Mint and Burn are watched through one log subscription so that the Sync emitted before each Swap is always applied first
+	parsedABI, err := Uniswapv2MetaData.GetAbi()
+	if err != nil {
+		return false, fmt.Errorf("%w: %v ABI: %v", utils.ErrUnwatchable, DEXSymbol, err)
+	}
+	syncTopic := parsedABI.Events["Sync"].ID
+	swapTopic := parsedABI.Events["Swap"].ID
+	mintTopic := parsedABI.Events["Mint"].ID
+	burnTopic := parsedABI.Events["Burn"].ID
+
+	logChan := make(chan types.Log)
+	subscription, err := ethClient.SubscribeFilterLogs(ctx, ethereum.FilterQuery{
+		Addresses: []common.Address{poolAddress},
+		Topics:    [][]common.Hash{{syncTopic, swapTopic, mintTopic, burnTopic}},
+	}, logChan)
+	if err != nil {
+		return false, err
+	}
+	defer subscription.Unsubscribe()
+	log.Printf("[%v] Subscribed to Sync/Swap/Mint/Burn events (%v/%v) (pool: %v) (fee: %v)", DEXSymbol, pool.Token0.Symbol, pool.Token1.Symbol, poolAddress, pool.Fee)
+
+	// Quote one whole token in each direction
+	unitToken0 := models.OneToken(pool.Token0).Raw
+	unitToken1 := models.OneToken(pool.Token1).Raw
+
+	// Handle incoming Sync/Swap/Mint/Burn events
+	received := false
+	for {
+		select {
+		case vLog := <-logChan:
+			received = true
+			if vLog.Removed {
+				continue
+			}
+
+			// Sync carries the reserves after every change to the pair
+			if vLog.Topics[0] == syncTopic {
+				syncEvent, err := poolContract.ParseSync(vLog)
+				if err != nil {
+					log.Printf("Failed to parse Sync event: %v", err)
+					continue
+				}
+				reserve0, reserve1 = syncEvent.Reserve0, syncEvent.Reserve1
+				continue
+			}
+
+			// Liquidity changes, the reserves already followed from their Sync
+			if vLog.Topics[0] == mintTopic {
+				mintEvent, err := poolContract.ParseMint(vLog)
+				if err != nil {
+					log.Printf("Failed to parse Mint event: %v", err)
+					continue
+				}
+				eventBus.Mints.Publish(models.LiquidityEvent{
+					DEXSymbol:   DEXSymbol,
+					PoolAddress: pool.Address,
+					PoolKey:     pool.Key(),
+					BlockNumber: vLog.BlockNumber,
+					Amount0:     mintEvent.Amount0,
+					Amount1:     mintEvent.Amount1,
+				})
+				continue
+			}
+			if vLog.Topics[0] == burnTopic {
+				burnEvent, err := poolContract.ParseBurn(vLog)
+				if err != nil {
+					log.Printf("Failed to parse Burn event: %v", err)
+					continue
+				}
+				eventBus.Burns.Publish(models.LiquidityEvent{
+					DEXSymbol:   DEXSymbol,
+					PoolAddress: pool.Address,
+					PoolKey:     pool.Key(),
+					BlockNumber: vLog.BlockNumber,
+					Amount0:     burnEvent.Amount0,
+					Amount1:     burnEvent.Amount1,
+				})
+				continue
+			}
+
+			swapEvent, err := poolContract.ParseSwap(vLog)
+			if err != nil {
+				log.Printf("Failed to parse Swap event: %v", err)
+				continue
+			}
+
+			// -- event latency --
+
+			// Fetch the block header using the BlockNumber from the swap event
+			blockHeader, err := ethClient.HeaderByNumber(ctx, big.NewInt(int64(swapEvent.Raw.BlockNumber)))
+			if err != nil {
+				log.Printf("Failed to fetch block header: %v", err)
+				continue
+			}
+
+			blockTimestamp := time.Unix(int64(blockHeader.Time), 0)
+			currentTime := time.Now()
+			latency := currentTime.Sub(blockTimestamp)
+
+			// -- event latency --
+
+			// Exact constant-product output (fee included) for one token in each direction
+			amountOut := utils.GetAmountOutConstantProduct(unitToken0, reserve0, reserve1, pool.Fee)
+			backwardsAmountOut := utils.GetAmountOutConstantProduct(unitToken1, reserve1, reserve0, pool.Fee)
+
+			// Parsed objet
+			eventData := models.EventData{
+				DEXSymbol:               DEXSymbol,
+				PoolAddress:             pool.Address,
+				PoolKey:                 pool.Key(),
+				BlockNumber:             swapEvent.Raw.BlockNumber,
+				Latency:                 latency,
+				Fee:                     pool.Fee,
+				Token0Symbol:            pool.Token0.Symbol,
+				Token1Symbol:            pool.Token1.Symbol,
+				Token0ToToken1AmountOut: models.NewAmount(pool.Token1, amountOut),
+				Token1ToToken0AmountOut: models.NewAmount(pool.Token0, backwardsAmountOut),
+				Amount0:                 new(big.Int).Sub(swapEvent.Amount0In, swapEvent.Amount0Out),
+				Amount1:                 new(big.Int).Sub(swapEvent.Amount1In, swapEvent.Amount1Out),
+				Sender:                  swapEvent.Sender,
+				Recipient:               swapEvent.To,
+				Reserve0:                reserve0,
+				Reserve1:                reserve1,
+				Quoter:                  utils.ConstantProductQuoter{Token0: pool.Token0, Token1: pool.Token1, Reserve0: reserve0, Reserve1: reserve1, Fee: pool.Fee},
+			}
+			eventData.SetLog(swapEvent.Raw)
+
+			// Publish the structured data on the event bus
+			eventBus.Swaps.Publish(eventData)
+		case <-ctx.Done():
+			// Pool removed from the configuration
+			return received, ctx.Err()
+		case err := <-subscription.Err():
+			return received, err
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package uniswapv4
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"198/models"
+	"198/utils"
+)
+
+// Flags of the PoolKey fee and hooks address
+const (
+	DynamicFeeFlag             = 0x800000 // LPFeeLibrary.DYNAMIC_FEE_FLAG
+	hookPermissionsMask        = 0x3FFF   // Hooks.ALL_HOOK_MASK, permissions live in the lowest 14 bits of the address
+	beforeSwapReturnsDeltaFlag = 1 << 3   // Hooks.BEFORE_SWAP_RETURNS_DELTA_FLAG
+	afterSwapReturnsDeltaFlag  = 1 << 2   // Hooks.AFTER_SWAP_RETURNS_DELTA_FLAG
+	poolsSlot                  = 6        // StateLibrary.POOLS_SLOT
+	liquidityOffset            = 3        // StateLibrary.LIQUIDITY_OFFSET
+)
+
+type Uniswapv4Instance struct {
+	DEXSymbol string
+}
+
+func NewUniswapV4Instance(symbol ...string) Uniswapv4Instance {
+	instance := Uniswapv4Instance{
+		DEXSymbol: "UniswapV4", // default value
+	}
+	if len(symbol) > 0 && symbol[0] != "" {
+		instance.DEXSymbol = symbol[0]
+	}
+	return instance
+}
+
+// PoolID computes the V4 PoolId (keccak256 of the abi-encoded PoolKey) of a pool.
+// Pool.Fee, Pool.TickSpacing and Pool.Hooks together with the token addresses form the PoolKey.
+func PoolID(pool *models.Pool) (string, error) {
+	uint24Type, _ := abi.NewType("uint24", "", nil)
+	int24Type, _ := abi.NewType("int24", "", nil)
+	addressType, _ := abi.NewType("address", "", nil)
+	arguments := abi.Arguments{{Type: addressType}, {Type: addressType}, {Type: uint24Type}, {Type: int24Type}, {Type: addressType}}
+
+	encoded, err := arguments.Pack(
+		common.HexToAddress(pool.Token0.Address),
+		common.HexToAddress(pool.Token1.Address),
+		pool.Fee,
+		big.NewInt(int64(pool.TickSpacing)),
+		common.HexToAddress(pool.Hooks),
+	)
+	if err != nil {
+		return "", err
+	}
+	return crypto.Keccak256Hash(encoded).Hex(), nil
+}
+
+// WatchPairSwaps follows one pool of the PoolManager, identified by its PoolId (Pool.ID).
+// Pool.Address is the PoolManager; events of every pool are emitted there, so the subscription filters on the id topic.
+// A failed subscription is replaced and slot0 and the liquidity read again.
+func (u Uniswapv4Instance) WatchPairSwaps(ctx context.Context, ethClient *ethclient.Client, pool *models.Pool, eventBus *models.Bus) {
+	utils.Resubscribe(ctx, fmt.Sprintf("[%v] [%v]", u.DEXSymbol, pool.ID), func() (bool, error) {
+		return u.watchPool(ctx, ethClient, pool, eventBus)
+	})
+}
+
+// watchPool follows one subscription to the pool's events until it fails, reporting whether it delivered any event
+func (u Uniswapv4Instance) watchPool(ctx context.Context, ethClient *ethclient.Client, pool *models.Pool, eventBus *models.Bus) (bool, error) {
+	DEXSymbol := u.DEXSymbol
+
+	// Specify the PoolManager contract address and the pool id
+	managerAddress := common.HexToAddress(pool.Address)
+	if pool.ID == "" {
+		return false, fmt.Errorf("%w: pool %v/%v has no PoolId", utils.ErrUnwatchable, pool.Token0.Symbol, pool.Token1.Symbol)
+	}
+	poolId := common.HexToHash(pool.ID)
+	expected, err := PoolID(pool)
+	if err != nil {
+		return false, fmt.Errorf("%w: PoolKey: %v", utils.ErrUnwatchable, err)
+	}
+	if !strings.EqualFold(expected, pool.ID) {
+		log.Printf("WARNING: [%v] PoolId %v does not match the configured PoolKey (%v)", DEXSymbol, pool.ID, expected)
+	}
+
+	// Hooks may take or return deltas around the swap, which the local price model cannot see
+	hooks := common.HexToAddress(pool.Hooks)
+	hookFlags := (uint16(hooks[common.AddressLength-2])<<8 | uint16(hooks[common.AddressLength-1])) & hookPermissionsMask
+	if hookFlags&(beforeSwapReturnsDeltaFlag|afterSwapReturnsDeltaFlag) != 0 {
+		log.Printf("WARNING: [%v] Pool %v hooks %v can modify swap deltas, quotes may be off", DEXSymbol, pool.ID, hooks)
+	}
+	dynamicFee := pool.Fee != nil && pool.Fee.Int64() == DynamicFeeFlag
+
+	// Create an instance of the PoolManager contract
+	managerContract, err := NewUniswapv4(managerAddress, ethClient)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v contract: %v", utils.ErrUnwatchable, DEXSymbol, err)
+	}
+
+	// Bootstrap slot0 and liquidity from PoolManager storage (StateLibrary layout)
+	stateSlot := crypto.Keccak256Hash(poolId.Bytes(), common.BigToHash(big.NewInt(poolsSlot)).Bytes())
+	slot0, err := managerContract.Extsload(&bind.CallOpts{Context: ctx}, stateSlot)
+	if err != nil {
+		return false, fmt.Errorf("reading slot0: %w", err)
+	}
+	// slot0 packs (from the lowest bits) sqrtPriceX96 (160) | tick (24) | protocolFee (24) | lpFee (24)
+	sqrtPriceX96 := new(big.Int).SetBytes(slot0[12:])
+	lpFee := new(big.Int).SetBytes(slot0[3:6])
+	if !dynamicFee && pool.Fee != nil {
+		lpFee = pool.Fee
+	}
+	liquiditySlot := common.BigToHash(new(big.Int).Add(stateSlot.Big(), big.NewInt(liquidityOffset)))
+	liquidityWord, err := managerContract.Extsload(&bind.CallOpts{Context: ctx}, liquiditySlot)
+	if err != nil {
+		return false, fmt.Errorf("reading liquidity: %w", err)
+	}
+	liquidity := new(big.Int).SetBytes(liquidityWord[16:]) // uint128
+	tick := new(big.Int).SetBytes(slot0[9:12])
+	if tick.Bit(23) == 1 {
+		tick.Sub(tick, big.NewInt(1<<24))
+	}
+	if sqrtPriceX96.Sign() == 0 {
+		log.Printf("[%v] Pool %v is not initialized yet, waiting for Initialize", DEXSymbol, pool.ID)
+	}
+
+	// Initialize, Swap and ModifyLiquidity for this pool id, in log order
+	parsedABI, err := Uniswapv4MetaData.GetAbi()
+	if err != nil {
+		return false, fmt.Errorf("%w: %v ABI: %v", utils.ErrUnwatchable, DEXSymbol, err)
+	}
+	initializeTopic := parsedABI.Events["Initialize"].ID
+	swapTopic := parsedABI.Events["Swap"].ID
+	modifyLiquidityTopic := parsedABI.Events["ModifyLiquidity"].ID
+
+	logChan := make(chan types.Log)
+	subscription, err := ethClient.SubscribeFilterLogs(ctx, ethereum.FilterQuery{
+		Addresses: []common.Address{managerAddress},
+		Topics:    [][]common.Hash{{initializeTopic, swapTopic, modifyLiquidityTopic}, {poolId}},
+	}, logChan)
+	if err != nil {
+		return false, err
+	}
+	defer subscription.Unsubscribe()
+	log.Printf("[%v] Subscribed to Swap events (%v/%v) (pool: %v) (fee: %v) (dynamic fee: %v) (hooks: %v)", DEXSymbol, pool.Token0.Symbol, pool.Token1.Symbol, pool.ID, lpFee, dynamicFee, hooks)
+
+	// Handle incoming PoolManager events
+	received := false
+	for {
+		select {
+		case vLog := <-logChan:
+			received = true
+			if vLog.Removed {
+				continue
+			}
+
+			switch vLog.Topics[0] {
+			case initializeTopic:
+				initializeEvent, err := managerContract.ParseInitialize(vLog)
+				if err != nil {
+					log.Printf("Failed to parse Initialize event: %v", err)
+					continue
+				}
+				sqrtPriceX96, tick = initializeEvent.SqrtPriceX96, initializeEvent.Tick
+				log.Printf("[%v] Pool %v initialized (hooks: %v) (tick spacing: %v)", DEXSymbol, pool.ID, initializeEvent.Hooks, initializeEvent.TickSpacing)
+				continue
+			case modifyLiquidityTopic:
+				modifyEvent, err := managerContract.ParseModifyLiquidity(vLog)
+				if err != nil {
+					log.Printf("Failed to parse ModifyLiquidity event: %v", err)
+					continue
+				}
+				// Only positions around the current tick change the active liquidity
+				if modifyEvent.TickLower.Cmp(tick) <= 0 && tick.Cmp(modifyEvent.TickUpper) < 0 {
+					liquidity = new(big.Int).Add(liquidity, modifyEvent.LiquidityDelta)
+				}
+				liquidityEvent := models.LiquidityEvent{
+					DEXSymbol:   DEXSymbol,
+					PoolAddress: pool.Address,
+					PoolKey:     pool.Key(),
+					BlockNumber: vLog.BlockNumber,
+					Liquidity:   new(big.Int).Abs(modifyEvent.LiquidityDelta),
+				}
+				if modifyEvent.LiquidityDelta.Sign() > 0 {
+					eventBus.Mints.Publish(liquidityEvent)
+				} else {
+					eventBus.Burns.Publish(liquidityEvent)
+				}
+				continue
+			}
+
+			swapEvent, err := managerContract.ParseSwap(vLog)
+			if err != nil {
+				log.Printf("Failed to parse Swap event: %v", err)
+				continue
+			}
+			sqrtPriceX96, liquidity, tick = swapEvent.SqrtPriceX96, swapEvent.Liquidity, swapEvent.Tick
+
+			// The Swap event carries the fee actually charged, which is how dynamic fees become visible
+			swapFee := swapEvent.Fee
+
+			// -- event latency --
+
+			// Fetch the block header using the BlockNumber from the swap event
+			blockHeader, err := ethClient.HeaderByNumber(ctx, big.NewInt(int64(swapEvent.Raw.BlockNumber)))
+			if err != nil {
+				log.Printf("Failed to fetch block header: %v", err)
+				continue
+			}
+
+			blockTimestamp := time.Unix(int64(blockHeader.Time), 0)
+			currentTime := time.Now()
+			latency := currentTime.Sub(blockTimestamp)
+
+			// -- event latency --
+
+			// Exact output of one whole token at the current price, net of the fee and rounded down like the pool
+			amountOut, err := utils.QuoteAtSqrtPrice(sqrtPriceX96, models.OneToken(pool.Token0).Raw, swapFee, true)
+			if err != nil {
+				log.Printf("Error quoting %v -> %v: %v", pool.Token0.Symbol, pool.Token1.Symbol, err)
+				continue
+			}
+
+			// Token1 -> Token0
+			backwardsAmountOut, err := utils.QuoteAtSqrtPrice(sqrtPriceX96, models.OneToken(pool.Token1).Raw, swapFee, false)
+			if err != nil {
+				log.Printf("Error quoting %v -> %v: %v", pool.Token1.Symbol, pool.Token0.Symbol, err)
+				continue
+			}
+
+			// Parsed objet
+			eventData := models.EventData{
+				DEXSymbol:               DEXSymbol,
+				PoolAddress:             pool.Address,
+				PoolKey:                 pool.Key(),
+				BlockNumber:             swapEvent.Raw.BlockNumber,
+				Latency:                 latency,
+				Fee:                     swapFee,
+				Token0Symbol:            pool.Token0.Symbol,
+				Token1Symbol:            pool.Token1.Symbol,
+				Token0ToToken1AmountOut: models.NewAmount(pool.Token1, amountOut),
+				Token1ToToken0AmountOut: models.NewAmount(pool.Token0, backwardsAmountOut),
+				Amount0:                 new(big.Int).Neg(swapEvent.Amount0), // V4 reports the swapper's deltas
+				Amount1:                 new(big.Int).Neg(swapEvent.Amount1),
+				SqrtPriceX96:            swapEvent.SqrtPriceX96,
+				Liquidity:               swapEvent.Liquidity,
+				Tick:                    swapEvent.Tick,
+				Sender:                  swapEvent.Sender,
+				Recipient:               swapEvent.Sender, // Output is settled with the caller (usually a router)
+			}
+			eventData.Reserve0, eventData.Reserve1 = utils.VirtualReserves(sqrtPriceX96, liquidity)
+			// The bitmap lives in the PoolManager's storage, the tick-spacing range around the tick is a safe bound
+			sqrtPriceLower, sqrtPriceUpper, err := utils.TickSpacingRange(int(tick.Int64()), pool.TickSpacing)
+			if err != nil {
+				log.Printf("Failed to bound the tick range: %v", err)
+				continue
+			}
+			eventData.Quoter = utils.ConcentratedLiquidityQuoter{Token0: pool.Token0, Token1: pool.Token1, SqrtPriceX96: sqrtPriceX96, Liquidity: liquidity, SqrtPriceLowerX96: sqrtPriceLower, SqrtPriceUpperX96: sqrtPriceUpper, Fee: swapFee}
+			eventData.SetLog(swapEvent.Raw)
+
+			// Publish the structured data on the event bus
+			eventBus.Swaps.Publish(eventData)
+		case <-ctx.Done():
+			// Pool removed from the configuration
+			return received, ctx.Err()
+		case err := <-subscription.Err():
+			return received, err
+		}
+	}
+}
@@ -1,21 +1,54 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
+	"math"
+	"math/big"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/joho/godotenv"
 
+	"198/analytics"
+	"198/candles"
 	"198/config"
+	"198/crosscheck"
 	"198/dex"
 	"198/models"
+	"198/router"
 	"198/strategy"
 	"198/utils"
 )
 
 func main() {
+	// Research export of the stored candles, run instead of the bot
+	if len(os.Args) > 1 && os.Args[1] == "export-candles" {
+		if err := exportCandles(os.Args[2:]); err != nil {
+			log.Fatalf("Failed to export candles: %v", err)
+		}
+		return
+	}
+
+	// Chains to run (see config.Chains)
+	chainsFlag := flag.String("chains", "polygon", "comma-separated list of chains to run")
+	// Optional directory of <chain>.json files overriding the compiled-in tokens, pools and DEXes (reloaded on change)
+	configDir := flag.String("config-dir", "", "directory holding <chain>.json configuration files")
+	// Optional address serving route quotes at /<chain>/quote (see router.NewHandler)
+	quoteAddr := flag.String("quote-addr", "", "listen address of the quote endpoint, e.g. :8080")
+	// Optional store of the candles of every swap under <candles-dir>/<chain> (see export-candles)
+	candlesDir := flag.String("candles-dir", "", "directory of the candle store, e.g. ./logs/candles")
+	flag.Parse()
+
 	// Setup logging
 	logFile, err := utils.SetupLogging()
 	if err != nil {
@@ -23,63 +56,605 @@ func main() {
 	}
 	defer logFile.Close()
 
-	// Load environment variables
-	err = godotenv.Load(".env.polygon")
-	if err != nil {
-		log.Fatalf("Error loading .env file: %v", err)
+	// Resolve every chain before starting any of them
+	var chains []*config.Chain
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(*chainsFlag, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		chain, err := config.GetChain(name)
+		if err != nil {
+			log.Fatalf("Unknown chain %q: %v", name, err)
+		}
+		seen[name] = true
+		chains = append(chains, chain)
+	}
+
+	if *quoteAddr != "" {
+		go func() {
+			log.Fatalf("Quote endpoint stopped: %v", http.ListenAndServe(*quoteAddr, nil))
+		}()
+	}
+
+	// Each chain runs with its own tokens, pools and watchers
+	var wg sync.WaitGroup
+	for _, chain := range chains {
+		wg.Add(1)
+		go func(chain *config.Chain) {
+			defer wg.Done()
+			configPath := ""
+			if *configDir != "" {
+				configPath = filepath.Join(*configDir, chain.Name+".json")
+			}
+			storePath := ""
+			if *candlesDir != "" {
+				storePath = filepath.Join(*candlesDir, chain.Name)
+			}
+			runChain(chain, configPath, storePath)
+		}(chain)
+	}
+	wg.Wait()
+}
+
+// runChain connects to one chain and runs its watchers and strategy loop.
+// With a configPath, tokens, pools, DEXes and strategy thresholds come from that file and follow its changes.
+// With a candlesPath, every swap is aggregated into candles stored there.
+func runChain(chain *config.Chain, configPath, candlesPath string) {
+	// Load environment variables (kept per chain rather than in the process environment)
+	env, err := godotenv.Read(chain.EnvFile)
+	if err != nil && len(chain.RPCEndpoints) == 0 {
+		log.Fatalf("[%v] Error loading %v file: %v", chain.Name, chain.EnvFile, err)
+	}
+	NODE_URL := env["NODE_URL"]
+	NODE_NAME := env["NODE_NAME"]
+	if NODE_URL == "" && len(chain.RPCEndpoints) > 0 {
+		NODE_URL = chain.RPCEndpoints[0]
+		NODE_NAME = chain.Name
 	}
-	NODE_URL := os.Getenv("NODE_URL")
-	NODE_NAME := os.Getenv("NODE_NAME")
 
-	// Connect to the Polygon node (WSS)
+	// Connect to the chain node (WSS)
 	ethClient, err := ethclient.Dial(NODE_URL)
 	if err != nil {
-		log.Fatalf("Failed to connect to the node via ethclient: %v", err)
+		log.Fatalf("[%v] Failed to connect to the node via ethclient: %v", chain.Name, err)
 	}
 	defer ethClient.Close()
-	log.Printf("Connected to %v node", NODE_NAME)
+	log.Printf("[%v] Connected to %v node (chain id: %v)", chain.Name, NODE_NAME, chain.ChainID)
 
-	// Instantiate tokenList
-	initialTokens := config.InitialTokens
-	tokenList, err := models.NewTokenListFromSlice(initialTokens)
-	if err != nil {
-		log.Fatalf("Failed to construct tokenList: %v", err)
+	// Compiled-in configuration, replaced by the configuration file when there is one
+	dexes, settings := chain.DEXes, config.DefaultStrategy
+	var fileConfig *config.FileConfig
+	var tokenList *models.TokenList
+	if configPath != "" {
+		fileConfig, err = config.LoadFile(configPath, chain.ChainID)
+		if err != nil {
+			log.Fatalf("[%v] Failed to load configuration %v: %v", chain.Name, configPath, err)
+		}
+		tokenList, err = fileConfig.BuildTokens()
+		if err != nil {
+			log.Fatalf("[%v] Failed to construct tokenList: %v", chain.Name, err)
+		}
+		settings = fileConfig.Strategy
+		if fileConfig.TokenList != nil && fileConfig.TokenList.Verify {
+			if err := models.VerifyTokens(ethClient, tokenList.ListTokens()); err != nil {
+				log.Fatalf("[%v] Token verification failed: %v", chain.Name, err)
+			}
+		}
+		if len(fileConfig.DEXes) > 0 {
+			dexes = fileConfig.DEXes
+		}
+		log.Printf("[%v] Loaded configuration %v", chain.Name, configPath)
+	} else if tokenList, err = chain.BuildTokens(); err != nil {
+		log.Fatalf("[%v] Failed to construct tokenList: %v", chain.Name, err)
 	}
 
-	// Centralized (universal) channel for aggregate events
-	universalChan := make(chan models.EventData)
+	// Event bus shared by the watchers, the strategy and any other consumer
+	// The strategy only needs the latest state of each pool, so a slow strategy loop never holds up the watchers
+	eventBus := models.NewBus()
+	swaps := eventBus.Swaps.Subscribe("strategy", 1024, models.Coalesce, func(event models.EventData) string {
+		return event.PoolKey
+	})
+	defer swaps.Unsubscribe()
+
+	// Swaps are collapsed to the latest state of each pool, the strategy loop drains the dirty pools
+	poolQueue := models.NewPoolQueue()
+	go func() {
+		for event := range swaps.C {
+			poolQueue.Push(event)
+		}
+	}()
+	reorgs := eventBus.Reorgs.Subscribe("log", 16, models.DropOldest, nil)
+	defer reorgs.Unsubscribe()
+	heads := eventBus.NewHeads.Subscribe("jobs", 1, models.DropOldest, nil)
+	defer heads.Unsubscribe()
 
 	// Instantiate pools
-	initialPools := config.InitialPools
-	poolList, err := models.NewPoolListFromSlice(initialPools)
+	var pools []*models.Pool
+	if fileConfig != nil {
+		pools, err = fileConfig.BuildPools(tokenList)
+	} else {
+		pools, err = chain.BuildPools(tokenList)
+	}
+	if err != nil {
+		log.Fatalf("[%v] Failed to resolve pools: %v", chain.Name, err)
+	}
+	poolList, err := models.NewPoolListFromSlice(pools)
+	if err != nil {
+		log.Fatalf("[%v] Failed to construct poolList: %v", chain.Name, err)
+	}
+
+	// Route quotes against the live pool state
+	http.Handle("/"+chain.Name+"/quote", router.NewHandler(poolList, tokenList))
+
+	// Opportunity lifecycles, persisted next to the logs
+	tracker, err := strategy.NewOpportunityTracker("./logs/opportunities_" + chain.Name + ".jsonl")
+	if err != nil {
+		log.Fatalf("[%v] Failed to open opportunity file: %v", chain.Name, err)
+	}
+	defer tracker.Close()
+
+	// Transactions looping through our pools, linked to the opportunities they closed
+	analyzer, err := strategy.NewCompetitorAnalyzer(ethClient, tokenList, chain.ChainID, "./logs/competitors_"+chain.Name+".jsonl")
 	if err != nil {
-		log.Printf("Failed to construct tokenList: %v", err)
+		log.Fatalf("[%v] Failed to open competitor file: %v", chain.Name, err)
+	}
+	competitorSwaps := eventBus.Swaps.Subscribe("competitors", 1024, models.DropOldest, nil)
+	defer competitorSwaps.Unsubscribe()
+	competitorHeads := eventBus.NewHeads.Subscribe("competitors", 4, models.DropOldest, nil)
+	defer competitorHeads.Unsubscribe()
+	closedChan := make(chan []*strategy.TrackedOpportunity, 64)
+	analyzerCtx, stopAnalyzer := context.WithCancel(context.Background())
+	defer stopAnalyzer()
+	go analyzer.Run(analyzerCtx, competitorSwaps.C, competitorHeads.C, closedChan)
+
+	// Local quotes compared with the DEXes' Quoter contracts, opened once the strategy enables it
+	var checker *crosscheck.Checker
+	openChecker := func() error {
+		if checker != nil || settings.CrossCheck == nil {
+			return nil
+		}
+		var err error
+		checker, err = crosscheck.NewChecker(crosscheck.NewContractReference(ethClient, dexes), "./logs/crosscheck_"+chain.Name+".jsonl")
+		return err
+	}
+	if err := openChecker(); err != nil {
+		log.Fatalf("[%v] Failed to open cross-check file: %v", chain.Name, err)
+	}
+	defer func() {
+		if checker != nil {
+			checker.Close()
+		}
+	}()
+
+	// Rolling volumes, fees and (Algebra) volatility per pool, recorded once the strategy enables their reports
+	var poolAnalytics *analytics.Analytics
+	var analyticsSwaps *models.Subscription[models.EventData]
+	openAnalytics := func() error {
+		if poolAnalytics != nil || settings.Analytics == nil {
+			return nil
+		}
+		opened, err := analytics.NewAnalytics(ethClient, "./logs/analytics_"+chain.Name+".jsonl")
+		if err != nil {
+			return err
+		}
+		swaps := eventBus.Swaps.Subscribe("analytics", 1024, models.DropOldest, nil)
+		heads := eventBus.NewHeads.Subscribe("analytics", 4, models.DropOldest, nil)
+		go func() {
+			defer swaps.Unsubscribe()
+			defer heads.Unsubscribe()
+			opened.Run(analyzerCtx, swaps.C, heads.C)
+		}()
+		poolAnalytics, analyticsSwaps = opened, swaps
+		return nil
+	}
+	if err := openAnalytics(); err != nil {
+		log.Fatalf("[%v] Failed to open analytics file: %v", chain.Name, err)
+	}
+
+	// OHLCV candles of every pool and pair
+	if candlesPath != "" {
+		store, err := candles.OpenStore(candlesPath)
+		if err != nil {
+			log.Fatalf("[%v] Failed to open candle store: %v", chain.Name, err)
+		}
+		defer store.Close()
+		// The store never holds up the publishers, lost swaps are logged by the aggregator
+		candleSwaps := eventBus.Swaps.Subscribe("candles", 1024, models.DropOldest, nil)
+		defer candleSwaps.Unsubscribe()
+		candleHeads := eventBus.NewHeads.Subscribe("candles", 4, models.DropOldest, nil)
+		defer candleHeads.Unsubscribe()
+		go candles.NewAggregator(store, poolList).Run(candleSwaps.C, candleHeads.C, candleSwaps.Dropped)
 	}
 
+	// Average ticks of the pools' oracles, opportunities far from them are marked suspicious
+	twapOracle := strategy.NewTWAPOracle(ethClient)
+
+	// Cycles through each pool, rebuilt whenever the pool set changes
+	cycleIndex := strategy.NewCycleIndex(tokenList, poolList.Snapshot())
+
+	// Running watchers by pool key, cancelled when their pool leaves the configuration
+	// The pools as configured are kept apart, since dynamic fees change Pool.Fee in the PoolList
+	watchers := make(map[string]context.CancelFunc)
+	definitions := make(map[string]*models.Pool)
+	startWatcher := func(pool *models.Pool) {
+		dexImpl := dex.DEXImplementations[pool.DEX]
+		if _, ok := dexes[pool.DEX]; !ok {
+			log.Printf("[%v] WARNING: %s is not registered for this chain", chain.Name, pool.DEX)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		watchers[pool.Key()] = cancel
+		definitions[pool.Key()] = pool
+
+		// Start listener for the pool
+		go dexImpl.WatchPairSwaps(ctx, ethClient, pool, eventBus)
+	}
+	defer func() {
+		for _, cancel := range watchers {
+			cancel()
+		}
+	}()
+
+	// Follow new blocks and reorgs
+	headsCtx, stopHeads := context.WithCancel(context.Background())
+	defer stopHeads()
+	go utils.WatchHeads(headsCtx, ethClient, eventBus)
+
 	// Listen to pools on each DEX (w/ delay to avoid high API/s use for initialization)
 	allPools := poolList.ListPools()
 	for _, pool := range allPools {
-		dexImpl, ok := dex.DEXImplementations[pool.DEX]
-		if !ok {
-			log.Fatalf("DEX implementation for %s not found", pool.DEX)
+		if _, ok := dex.DEXImplementations[pool.DEX]; !ok {
+			log.Fatalf("[%v] DEX implementation for %s not found", chain.Name, pool.DEX)
 		}
-
-		// Start listener for the pool
-		go dexImpl.WatchPairSwaps(ethClient, pool, universalChan)
+		startWatcher(pool)
 
 		// Wait for 0.5 seconds before starting the next listener (prevent spamming network)
 		time.Sleep(500 * time.Millisecond)
 	}
 
-	// Process parsed events
-	for event := range universalChan {
-		// Update rates for associated pool
-		poolList.UpdatePoolAmountOutsByAddress(event.PoolAddress, event.Token0ToToken1AmountOut, event.Token1ToToken0AmountOut)
+	// Follow changes of the configuration file
+	reloadChan := make(chan *config.FileConfig)
+	if configPath != "" {
+		done := make(chan struct{})
+		defer close(done)
+		err := config.WatchFile(configPath, chain.ChainID, done, func(fc *config.FileConfig) {
+			select {
+			case reloadChan <- fc:
+			case <-done:
+			}
+		})
+		if err != nil {
+			log.Fatalf("[%v] Failed to watch configuration %v: %v", chain.Name, configPath, err)
+		}
+	}
+
+	// Apply a reloaded configuration: new tokens, added/removed pools, DEXes and thresholds
+	applyConfig := func(fc *config.FileConfig) {
+		reloaded, err := fc.BuildTokens()
+		if err != nil {
+			log.Printf("[%v] Ignoring reloaded configuration: %v", chain.Name, err)
+			return
+		}
+		// Tokens are shared by pools, cycles and opportunities, so a changed definition takes a restart
+		var newTokens []*models.Token
+		for _, token := range reloaded.ListTokens() {
+			current, err := tokenList.GetTokenBySymbol(token.Symbol)
+			if err != nil {
+				if existing, err := tokenList.GetTokenByAddress(token.Address); err == nil {
+					log.Printf("[%v] Ignoring reloaded configuration: token %v was renamed %v, restart to apply", chain.Name, existing.Symbol, token.Symbol)
+					return
+				}
+				newTokens = append(newTokens, token)
+				continue
+			}
+			if !sameToken(current, token) {
+				log.Printf("[%v] Ignoring reloaded configuration: token %v changed, restart to apply", chain.Name, token.Symbol)
+				return
+			}
+		}
+		if fc.TokenList != nil && fc.TokenList.Verify {
+			if err := models.VerifyTokens(ethClient, newTokens); err != nil {
+				log.Printf("[%v] Ignoring reloaded configuration: %v", chain.Name, err)
+				return
+			}
+		}
+		for _, token := range newTokens {
+			if err := tokenList.AddToken(*token); err != nil {
+				log.Printf("[%v] Failed to add token %v: %v", chain.Name, token.Symbol, err)
+			}
+		}
+		pools, err := fc.BuildPools(tokenList)
+		if err != nil {
+			log.Printf("[%v] Ignoring reloaded configuration: %v", chain.Name, err)
+			return
+		}
+
+		configured := make(map[string]*models.Pool)
+		for _, pool := range pools {
+			if _, ok := dex.DEXImplementations[pool.DEX]; !ok {
+				log.Printf("[%v] Skipping pool %v: DEX implementation for %s not found", chain.Name, pool.Key(), pool.DEX)
+				continue
+			}
+			configured[pool.Key()] = pool
+		}
+
+		// Stop pools that were removed or whose definition changed
+		var removed []string
+		for _, pool := range poolList.ListPools() {
+			definition, ok := definitions[pool.Key()]
+			if !ok {
+				definition = pool
+			}
+			if next, ok := configured[pool.Key()]; ok && samePool(definition, next) {
+				delete(configured, pool.Key())
+				continue
+			}
+			if cancel, ok := watchers[pool.Key()]; ok {
+				cancel()
+				delete(watchers, pool.Key())
+			}
+			delete(definitions, pool.Key())
+			if err := poolList.RemovePoolByKey(pool.Key()); err != nil {
+				log.Printf("[%v] Failed to remove pool %v: %v", chain.Name, pool.Key(), err)
+				continue
+			}
+			removed = append(removed, pool.Key())
+			log.Printf("[%v] Stopped pool %v (%v)", chain.Name, pool.Key(), pool.DEX)
+		}
+		// Their open opportunities would never be re-evaluated
+		if len(removed) > 0 {
+			tracker.PoolsRemoved(removed, poolList.Snapshot().BlockNumber)
+		}
+
+		// Start the added ones
+		for key, pool := range configured {
+			if err := poolList.AddPool(*pool); err != nil {
+				log.Printf("[%v] Failed to add pool %v: %v", chain.Name, key, err)
+				continue
+			}
+			added, _ := poolList.GetPoolByKey(key)
+			startWatcher(added)
+			log.Printf("[%v] Started pool %v (%v)", chain.Name, key, pool.DEX)
+		}
+
+		// Drop the removed tokens, which no configured pool references anymore
+		for _, token := range tokenList.ListTokens() {
+			if _, err := reloaded.GetTokenBySymbol(token.Symbol); err == nil {
+				continue
+			}
+			if err := tokenList.RemoveTokenBySymbol(token.Symbol); err != nil {
+				log.Printf("[%v] Failed to remove token %v: %v", chain.Name, token.Symbol, err)
+				continue
+			}
+			log.Printf("[%v] Removed token %v", chain.Name, token.Symbol)
+		}
+
+		if len(fc.DEXes) > 0 {
+			dexes = fc.DEXes
+			if checker != nil {
+				checker.SetReference(crosscheck.NewContractReference(ethClient, dexes))
+			}
+		}
+		settings = fc.Strategy
+		if err := openChecker(); err != nil {
+			log.Printf("[%v] Failed to open cross-check file: %v", chain.Name, err)
+		}
+		if err := openAnalytics(); err != nil {
+			log.Printf("[%v] Failed to open analytics file: %v", chain.Name, err)
+		}
+		cycleIndex = strategy.NewCycleIndex(tokenList, poolList.Snapshot())
+		log.Printf("[%v] Reloaded configuration (%v pools) (minimum multiplier: %v)", chain.Name, len(poolList.ListPools()), settings.MinimumMultiplier)
+	}
+
+	// Periodic jobs run once at least their number of blocks passed, so a dropped head delays a job rather than skipping it
+	var inventoryJob, crossCheckJob, twapJob, analyticsJob periodicJob
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	// Process dirty pools and configuration changes
+	for {
+		select {
+		case fc := <-reloadChan:
+			applyConfig(fc)
+		case head := <-heads.C:
+			// Periodically compare Hold token balances with their targets
+			if inventory := settings.Inventory; inventory != nil && inventoryJob.due(head.BlockNumber, inventory.CheckBlocks) {
+				go checkInventory(chain.Name, ethClient, tokenList, poolList.Snapshot(), inventory)
+			}
+			// Periodically validate the local quoters against on-chain quotes
+			if crossCheck := settings.CrossCheck; crossCheck != nil && checker != nil && crossCheckJob.due(head.BlockNumber, crossCheck.CheckBlocks) {
+				go func() {
+					if samples := checker.Round(jobsCtx, poolList, crossCheck.Samples, crossCheck.Tolerance); len(samples) == 0 {
+						return
+					}
+					for _, poolStats := range checker.Stats() {
+						log.Printf("[%v] Cross-check %v (%v): %v samples (flagged: %v) (failures: %v) (divergence: mean %.4f%%, max %.4f%%)", chain.Name, poolStats.PoolKey, poolStats.DEX, poolStats.Samples, poolStats.Flagged, poolStats.Failures, poolStats.MeanDivergence*100, poolStats.MaxDivergence*100)
+					}
+				}()
+			}
+			// Periodically read the pools' TWAPs
+			if twap := settings.TWAP; twap != nil && twapJob.due(head.BlockNumber, twap.RefreshBlocks) {
+				go twapOracle.Refresh(jobsCtx, poolList.Snapshot(), twap.Windows, head.BlockNumber)
+			}
+			// Periodically report the pools' rolling statistics
+			if stats := settings.Analytics; stats != nil && poolAnalytics != nil && analyticsJob.due(head.BlockNumber, stats.ReportBlocks) {
+				go poolAnalytics.Report(jobsCtx, poolList.Snapshot(), stats.Windows, head.BlockNumber, analyticsSwaps.Dropped())
+			}
+		case reorg := <-reorgs.C:
+			log.Printf("[%v] Reorg from block %v (%v -> %v)", chain.Name, reorg.BlockNumber, reorg.OldHash, reorg.NewHash)
+		case <-poolQueue.Ready():
+			// Update rates of every pool that changed since the last cycle
+			var updated []string
+			triggers := make(map[string]string)
+			for _, event := range poolQueue.Drain() {
+				err := poolList.UpdatePoolAmountOutsByKey(event.PoolKey, event.BlockNumber, event.Fee, event.Token0ToToken1AmountOut, event.Token1ToToken0AmountOut, event.Reserve0, event.Reserve1, event.Quoter)
+				if err != nil {
+					log.Printf("[%v] Failed to update pool %v: %v", chain.Name, event.PoolKey, err)
+					continue
+				}
+				updated = append(updated, event.PoolKey)
+				triggers[event.PoolKey] = event.LogID()
+
+				// Log swap event info
+				log.Printf("[%v] New swap event: %v", chain.Name, event)
+			}
+			if len(updated) == 0 {
+				continue
+			}
+			stats := poolQueue.Stats()
+			log.Printf("[%v] Evaluating %v updated pools (received: %v) (coalesced: %v)", chain.Name, len(updated), stats.Received, stats.Coalesced)
+
+			// Re-evaluate the cycles through the updated pools against one consistent snapshot
+			snapshot := poolList.Snapshot()
+			minimumMultiplier := big.NewFloat(settings.MinimumMultiplier)
+			opportunities := strategy.IncrementalArbitrageStrategy(cycleIndex, tokenList, snapshot, updated, minimumMultiplier)
+
+			// Same pair priced differently across DEXes or fee tiers
+			opportunities = append(opportunities, strategy.TwoPoolStrategy(tokenList, snapshot, updated, minimumMultiplier)...)
+
+			// Spot prices far from their TWAP were likely moved within the block
+			if twap := settings.TWAP; twap != nil {
+				strategy.ScreenOpportunities(twapOracle, snapshot, opportunities, twap.MaxDeviation)
+			}
+			// Published once screened, so subscribers see the suspicious pools
+			for _, opportunity := range opportunities {
+				eventBus.Opportunities.Publish(opportunity)
+			}
+
+			// Follow how long each opportunity lasts
+			// The analyzer waits on RPCs, so it never holds up the strategy loop: a full queue drops the batch
+			if closed := tracker.Round(snapshot, triggers, opportunities); len(closed) > 0 {
+				select {
+				case closedChan <- closed:
+				default:
+					log.Printf("[%v] Competitor analysis is behind, %v closed opportunities left unattributed", chain.Name, len(closed))
+				}
+			}
+		}
+	}
+}
+
+// checkInventory logs the rebalancing trades proposed by the inventory policy for the configured wallet.
+func checkInventory(chainName string, ethClient *ethclient.Client, tokenList *models.TokenList, snapshot *models.PoolSnapshot, inventory *config.InventoryConfig) {
+	balances, err := strategy.FetchHoldBalances(ethClient, tokenList, common.HexToAddress(inventory.Wallet))
+	if err != nil {
+		log.Printf("[%v] Failed to fetch Hold token balances: %v", chainName, err)
+		return
+	}
+
+	policy := strategy.InventoryPolicy{
+		Targets:   make(map[string]*big.Float),
+		Tolerance: inventory.Tolerance,
+	}
+	for symbol, target := range inventory.Targets {
+		policy.Targets[symbol] = big.NewFloat(target)
+	}
+	trades, err := policy.Rebalance(balances, snapshot)
+	if err != nil {
+		log.Printf("[%v] Failed to plan rebalancing: %v", chainName, err)
+		return
+	}
+	for _, trade := range trades {
+		log.Printf("[%v] Rebalance: %v -> %v via %v (%v)", chainName, trade.AmountIn, trade.ExpectedOut, trade.Pool.DEX, trade.Pool.Key())
+	}
+}
+
+// sameToken reports whether two token definitions are identical.
+func sameToken(a, b *models.Token) bool {
+	return a.Symbol == b.Symbol &&
+		a.Address == b.Address &&
+		a.Decimals == b.Decimals &&
+		a.Hold == b.Hold
+}
+
+// periodicJob tracks the last block a periodic job ran at.
+type periodicJob struct {
+	last uint64
+	ran  bool
+}
+
+// due reports whether at least every blocks passed since the job last ran, and if so records blockNumber as its last run.
+func (j *periodicJob) due(blockNumber, every uint64) bool {
+	if j.ran && blockNumber < j.last+every {
+		return false
+	}
+	j.last, j.ran = blockNumber, true
+	return true
+}
+
+// samePool reports whether two pool definitions would be watched identically.
+func samePool(a, b *models.Pool) bool {
+	return a.DEX == b.DEX &&
+		a.Address == b.Address &&
+		a.Fee.Cmp(b.Fee) == 0 &&
+		a.Token0.Symbol == b.Token0.Symbol &&
+		a.Token1.Symbol == b.Token1.Symbol &&
+		a.TickSpacing == b.TickSpacing &&
+		a.Hooks == b.Hooks
+}
 
-		// Log swap event info
-		log.Printf("New swap event: %v", event)
+// exportCandles writes the stored candles of one series as CSV, or lists the stored series.
+func exportCandles(args []string) error {
+	flags := flag.NewFlagSet("export-candles", flag.ExitOnError)
+	dir := flags.String("dir", "./logs/candles/polygon", "candle store of one chain")
+	series := flags.String("series", "", "pool key or pair (e.g. WETH/USDC, symbols sorted)")
+	interval := flags.Duration("interval", time.Minute, "candle interval: 1s, 1m or 1h")
+	from := flags.String("from", "", "first candle start, RFC 3339 (default: the first stored)")
+	to := flags.String("to", "", "end of the range, RFC 3339, exclusive (default: the last stored)")
+	out := flags.String("out", "", "CSV file to write (default: standard output)")
+	list := flags.Bool("list", false, "list the stored series of the interval instead")
+	flags.Parse(args)
 
-		// Identify and log triangular arbitrage opportunities
-		strategy.ArbitrageStrategy(ethClient, tokenList, poolList)
+	store, err := candles.OpenStoreReadOnly(*dir)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	if *list {
+		names, err := store.Series(*interval)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			fmt.Println(name)
+		}
+		return nil
+	}
+	if *series == "" {
+		return errors.New("-series is required")
+	}
+
+	start, end := int64(0), int64(math.MaxInt64)
+	if *from != "" {
+		parsed, err := time.Parse(time.RFC3339, *from)
+		if err != nil {
+			return err
+		}
+		start = parsed.Unix()
+	}
+	if *to != "" {
+		parsed, err := time.Parse(time.RFC3339, *to)
+		if err != nil {
+			return err
+		}
+		end = parsed.Unix()
+	}
+	stored, err := store.Range(*series, *interval, start, end)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		return candles.WriteCSV(os.Stdout, *series, *interval, stored)
+	}
+	file, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if err := candles.WriteCSV(file, *series, *interval, stored); err != nil {
+		file.Close()
+		return err
 	}
+	// Some file systems only report a failed write on close
+	return file.Close()
 }
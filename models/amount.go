@@ -0,0 +1,140 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Amount is a quantity of one token, held in raw base units (10^Decimals per whole token).
+// A nil *Amount means the quantity is unknown.
+type Amount struct {
+	Token *Token
+	Raw   *big.Int
+}
+
+var (
+	ErrTokenMismatch = errors.New("amounts are in different tokens")
+	ErrTooPrecise    = errors.New("amount has more decimals than the token")
+)
+
+// NewAmount binds a raw base-unit quantity to its token.
+func NewAmount(token *Token, raw *big.Int) *Amount {
+	return &Amount{Token: token, Raw: new(big.Int).Set(raw)}
+}
+
+// OneToken is one whole token, 10^Decimals base units.
+func OneToken(token *Token) *Amount {
+	return &Amount{Token: token, Raw: new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(token.Decimals)), nil)}
+}
+
+// ParseAmount reads a decimal string in whole tokens ("1.5") exactly; more decimals than the token has is an error.
+func ParseAmount(token *Token, value string) (*Amount, error) {
+	rat, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q", value)
+	}
+	rat.Mul(rat, new(big.Rat).SetInt(decimalsFactor(token)))
+	if !rat.IsInt() {
+		return nil, ErrTooPrecise
+	}
+	return &Amount{Token: token, Raw: new(big.Int).Set(rat.Num())}, nil
+}
+
+// AmountFromFloat converts whole tokens into base units, truncating anything below one base unit.
+func AmountFromFloat(token *Token, value *big.Float) *Amount {
+	scaled := new(big.Float).SetPrec(256).Mul(value, new(big.Float).SetInt(decimalsFactor(token)))
+	raw, _ := scaled.Int(nil)
+	return &Amount{Token: token, Raw: raw}
+}
+
+// Float is the amount in whole tokens, for display and float-based estimates.
+func (a *Amount) Float() *big.Float {
+	return new(big.Float).Quo(new(big.Float).SetInt(a.Raw), new(big.Float).SetInt(decimalsFactor(a.Token)))
+}
+
+// Float64 is Float as a float64.
+func (a *Amount) Float64() float64 {
+	value, _ := a.Float().Float64()
+	return value
+}
+
+// Sign returns -1, 0 or +1.
+func (a *Amount) Sign() int {
+	return a.Raw.Sign()
+}
+
+// Add returns a + b, refusing amounts of different tokens.
+func (a *Amount) Add(b *Amount) (*Amount, error) {
+	if !SameToken(a.Token, b.Token) {
+		return nil, ErrTokenMismatch
+	}
+	return &Amount{Token: a.Token, Raw: new(big.Int).Add(a.Raw, b.Raw)}, nil
+}
+
+// Sub returns a - b, refusing amounts of different tokens.
+func (a *Amount) Sub(b *Amount) (*Amount, error) {
+	if !SameToken(a.Token, b.Token) {
+		return nil, ErrTokenMismatch
+	}
+	return &Amount{Token: a.Token, Raw: new(big.Int).Sub(a.Raw, b.Raw)}, nil
+}
+
+// Cmp compares a and b like big.Int.Cmp, refusing amounts of different tokens.
+func (a *Amount) Cmp(b *Amount) (int, error) {
+	if !SameToken(a.Token, b.Token) {
+		return 0, ErrTokenMismatch
+	}
+	return a.Raw.Cmp(b.Raw), nil
+}
+
+// Rate is the number of whole output tokens per whole input token when a is received for in.
+func (a *Amount) Rate(in *Amount) *big.Float {
+	if in.Sign() == 0 {
+		return nil
+	}
+	return new(big.Float).Quo(a.Float(), in.Float())
+}
+
+// Decimal formats the amount in whole tokens with every significant decimal and no trailing zeros.
+func (a *Amount) Decimal() string {
+	factor := decimalsFactor(a.Token)
+	integer, fraction := new(big.Int).QuoRem(new(big.Int).Abs(a.Raw), factor, new(big.Int))
+	sign := ""
+	if a.Raw.Sign() < 0 {
+		sign = "-"
+	}
+	if fraction.Sign() == 0 {
+		return sign + integer.String()
+	}
+	digits := fmt.Sprintf("%0*s", a.Token.Decimals, fraction.String())
+	return sign + integer.String() + "." + strings.TrimRight(digits, "0")
+}
+
+// String formats the amount as "<whole tokens> <symbol>".
+func (a *Amount) String() string {
+	if a == nil {
+		return "<nil>"
+	}
+	return a.Decimal() + " " + a.Token.Symbol
+}
+
+// MarshalJSON encodes the token symbol, the exact raw amount and its decimal value.
+func (a *Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Token  string `json:"token"`
+		Raw    string `json:"raw"`
+		Amount string `json:"amount"`
+	}{a.Token.Symbol, a.Raw.String(), a.Decimal()})
+}
+
+// SameToken reports whether a and b are the same token (same contract).
+func SameToken(a, b *Token) bool {
+	return a == b || (a != nil && b != nil && strings.EqualFold(a.Address, b.Address))
+}
+
+func decimalsFactor(token *Token) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(token.Decimals)), nil)
+}
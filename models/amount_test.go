@@ -0,0 +1,81 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+var (
+	testUSDC = &Token{Symbol: "USDC", Address: "0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359", Decimals: 6}
+	testWETH = &Token{Symbol: "WETH", Address: "0x7ceB23fD6bC0adD59E62ac25578270cFf1b9f619", Decimals: 18}
+)
+
+func TestAmountArithmetic(t *testing.T) {
+	a := NewAmount(testUSDC, big.NewInt(1500000))
+	// Another *Token for the same contract, as loaded from a different list
+	b := NewAmount(&Token{Symbol: "USDC", Address: "0x3C499C542CEF5E3811E1192CE70D8CC03D5C3359", Decimals: 6}, big.NewInt(250000))
+
+	sum, err := a.Add(b)
+	if err != nil || sum.Raw.Int64() != 1750000 || sum.Token != testUSDC {
+		t.Errorf("Add = %v, %v", sum, err)
+	}
+	difference, err := b.Sub(a)
+	if err != nil || difference.Raw.Int64() != -1250000 {
+		t.Errorf("Sub = %v, %v", difference, err)
+	}
+	if cmp, err := a.Cmp(b); err != nil || cmp != 1 {
+		t.Errorf("Cmp = %v, %v", cmp, err)
+	}
+	if a.Raw.Int64() != 1500000 || b.Raw.Int64() != 250000 {
+		t.Errorf("operands changed to %v and %v", a, b)
+	}
+}
+
+func TestAmountTokenMismatch(t *testing.T) {
+	usdc := NewAmount(testUSDC, big.NewInt(1))
+	weth := NewAmount(testWETH, big.NewInt(1))
+
+	if sum, err := usdc.Add(weth); !errors.Is(err, ErrTokenMismatch) || sum != nil {
+		t.Errorf("Add = %v, %v, want %v", sum, err, ErrTokenMismatch)
+	}
+	if difference, err := usdc.Sub(weth); !errors.Is(err, ErrTokenMismatch) || difference != nil {
+		t.Errorf("Sub = %v, %v, want %v", difference, err, ErrTokenMismatch)
+	}
+	if _, err := usdc.Cmp(weth); !errors.Is(err, ErrTokenMismatch) {
+		t.Errorf("Cmp error = %v, want %v", err, ErrTokenMismatch)
+	}
+	// An amount with no token matches nothing but another amount with no token
+	if _, err := usdc.Add(&Amount{Raw: big.NewInt(1)}); !errors.Is(err, ErrTokenMismatch) {
+		t.Errorf("Add to a tokenless amount error = %v, want %v", err, ErrTokenMismatch)
+	}
+}
+
+func TestAmountMarshalJSON(t *testing.T) {
+	for _, tc := range []struct {
+		amount *Amount
+		want   string
+	}{
+		{NewAmount(testUSDC, big.NewInt(1500000)), `{"token":"USDC","raw":"1500000","amount":"1.5"}`},
+		{NewAmount(testUSDC, big.NewInt(-1)), `{"token":"USDC","raw":"-1","amount":"-0.000001"}`},
+		{OneToken(testWETH), `{"token":"WETH","raw":"1000000000000000000","amount":"1"}`},
+		{NewAmount(testWETH, big.NewInt(0)), `{"token":"WETH","raw":"0","amount":"0"}`},
+	} {
+		got, err := json.Marshal(tc.amount)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tc.want {
+			t.Errorf("json.Marshal(%v) = %s, want %s", tc.amount, got, tc.want)
+		}
+	}
+
+	// Inside a struct, a nil *Amount is null like any other nil pointer
+	got, err := json.Marshal(struct {
+		Profit *Amount `json:"profit"`
+	}{})
+	if err != nil || string(got) != `{"profit":null}` {
+		t.Errorf("json.Marshal(nil amount) = %s, %v", got, err)
+	}
+}
@@ -0,0 +1,216 @@
+package models
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Policy decides what a subscription does with new events once its buffer is full.
+type Policy int
+
+const (
+	Block      Policy = iota // The publisher waits until the subscriber makes room
+	DropNewest               // The new event is discarded
+	DropOldest               // The oldest buffered event is discarded to make room
+	Coalesce                 // A buffered event with the same key is replaced in place; otherwise behaves like DropOldest
+)
+
+// Topic fans events of one type out to any number of subscriptions.
+type Topic[T any] struct {
+	subscriptions []*Subscription[T]
+	mutex         sync.RWMutex
+}
+
+// Subscription is one consumer of a Topic with its own bounded buffer.
+// Events are received from C, which is closed by Unsubscribe.
+type Subscription[T any] struct {
+	Name string
+	C    <-chan T
+
+	topic     *Topic[T]
+	out       chan T
+	size      int
+	policy    Policy
+	key       func(T) string // Coalesce key, required by the Coalesce policy
+	queue     []T
+	keys      []string
+	closed    bool
+	mutex     sync.Mutex
+	cond      *sync.Cond
+	done      chan struct{}
+	dropped   atomic.Uint64
+	coalesced atomic.Uint64
+}
+
+// Subscribe registers a consumer with a buffer of size events handled according to policy.
+// key is only used by the Coalesce policy and may be nil otherwise.
+func (t *Topic[T]) Subscribe(name string, size int, policy Policy, key func(T) string) *Subscription[T] {
+	if size < 1 {
+		size = 1
+	}
+	if policy == Coalesce && key == nil {
+		policy = DropOldest
+	}
+	out := make(chan T)
+	s := &Subscription[T]{
+		Name:   name,
+		C:      out,
+		topic:  t,
+		out:    out,
+		size:   size,
+		policy: policy,
+		key:    key,
+		done:   make(chan struct{}),
+	}
+	s.cond = sync.NewCond(&s.mutex)
+
+	t.mutex.Lock()
+	t.subscriptions = append(t.subscriptions, s)
+	t.mutex.Unlock()
+
+	go s.pump()
+	return s
+}
+
+// Publish delivers an event to every subscription.
+// It only waits on subscriptions using the Block policy.
+func (t *Topic[T]) Publish(event T) {
+	t.mutex.RLock()
+	subscriptions := t.subscriptions
+	t.mutex.RUnlock()
+
+	for _, s := range subscriptions {
+		s.push(event)
+	}
+}
+
+// Unsubscribe removes the subscription from its topic and closes C.
+// Buffered events are discarded.
+func (s *Subscription[T]) Unsubscribe() {
+	t := s.topic
+	t.mutex.Lock()
+	for i, other := range t.subscriptions {
+		if other == s {
+			// Copy so that concurrent Publish calls keep iterating over the old slice
+			t.subscriptions = append(append([]*Subscription[T]{}, t.subscriptions[:i]...), t.subscriptions[i+1:]...)
+			break
+		}
+	}
+	t.mutex.Unlock()
+
+	s.mutex.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.done)
+		s.cond.Broadcast()
+	}
+	s.mutex.Unlock()
+}
+
+// Dropped returns the number of events discarded because the buffer was full.
+func (s *Subscription[T]) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Coalesced returns the number of buffered events replaced by a newer event with the same key.
+func (s *Subscription[T]) Coalesced() uint64 {
+	return s.coalesced.Load()
+}
+
+// Pending returns the number of buffered events.
+func (s *Subscription[T]) Pending() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.queue)
+}
+
+func (s *Subscription[T]) push(event T) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.closed {
+		return
+	}
+
+	var key string
+	if s.policy == Coalesce {
+		key = s.key(event)
+		for i := range s.queue {
+			if s.keys[i] == key {
+				s.queue[i] = event
+				s.coalesced.Add(1)
+				return
+			}
+		}
+	}
+
+	if len(s.queue) >= s.size {
+		switch s.policy {
+		case Block:
+			for len(s.queue) >= s.size && !s.closed {
+				s.cond.Wait()
+			}
+			if s.closed {
+				return
+			}
+		case DropNewest:
+			s.dropped.Add(1)
+			return
+		case DropOldest, Coalesce:
+			s.queue = s.queue[1:]
+			if s.policy == Coalesce {
+				s.keys = s.keys[1:]
+			}
+			s.dropped.Add(1)
+		}
+	}
+
+	s.queue = append(s.queue, event)
+	if s.policy == Coalesce {
+		s.keys = append(s.keys, key)
+	}
+	s.cond.Broadcast()
+}
+
+// pump hands buffered events to C in order until the subscription is closed
+func (s *Subscription[T]) pump() {
+	defer close(s.out)
+	for {
+		s.mutex.Lock()
+		for len(s.queue) == 0 && !s.closed {
+			s.cond.Wait()
+		}
+		if s.closed {
+			s.mutex.Unlock()
+			return
+		}
+		event := s.queue[0]
+		s.queue = s.queue[1:]
+		if s.policy == Coalesce {
+			s.keys = s.keys[1:]
+		}
+		s.cond.Broadcast()
+		s.mutex.Unlock()
+
+		select {
+		case s.out <- event:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// Bus carries every event type of the bot, one Topic per type.
+type Bus struct {
+	Swaps         Topic[EventData]
+	Mints         Topic[LiquidityEvent]
+	Burns         Topic[LiquidityEvent]
+	FeeChanges    Topic[FeeChangeEvent]
+	NewHeads      Topic[HeadEvent]
+	Reorgs        Topic[ReorgEvent]
+	Opportunities Topic[OpportunityEvent]
+}
+
+// NewBus initializes and returns an empty Bus.
+func NewBus() *Bus {
+	return &Bus{}
+}
@@ -0,0 +1,164 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+// publishHeld publishes the first event and waits until the pump holds it, so that the buffer is empty again:
+// the subscription then accepts exactly size more events before its policy applies
+func publishHeld[T any](t *testing.T, topic *Topic[T], s *Subscription[T], event T) {
+	t.Helper()
+	topic.Publish(event)
+	deadline := time.Now().Add(time.Second)
+	for s.Pending() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("the pump did not take the first event")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func receive[T comparable](t *testing.T, s *Subscription[T], want ...T) {
+	t.Helper()
+	for _, w := range want {
+		select {
+		case got := <-s.C:
+			if got != w {
+				t.Fatalf("received %v, want %v", got, w)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("nothing received, want %v", w)
+		}
+	}
+}
+
+func TestBusDropNewest(t *testing.T) {
+	var topic Topic[int]
+	s := topic.Subscribe("test", 2, DropNewest, nil)
+	defer s.Unsubscribe()
+
+	publishHeld(t, &topic, s, 1)
+	for _, event := range []int{2, 3, 4, 5} {
+		topic.Publish(event)
+	}
+	receive(t, s, 1, 2, 3)
+	if s.Dropped() != 2 {
+		t.Errorf("dropped = %d, want 2", s.Dropped())
+	}
+}
+
+func TestBusDropOldest(t *testing.T) {
+	var topic Topic[int]
+	s := topic.Subscribe("test", 2, DropOldest, nil)
+	defer s.Unsubscribe()
+
+	publishHeld(t, &topic, s, 1)
+	for _, event := range []int{2, 3, 4, 5} {
+		topic.Publish(event)
+	}
+	receive(t, s, 1, 4, 5)
+	if s.Dropped() != 2 {
+		t.Errorf("dropped = %d, want 2", s.Dropped())
+	}
+}
+
+func TestBusCoalesce(t *testing.T) {
+	type event struct {
+		key   string
+		value int
+	}
+	var topic Topic[event]
+	s := topic.Subscribe("test", 2, Coalesce, func(e event) string { return e.key })
+	defer s.Unsubscribe()
+
+	// A buffered event of the same key is replaced in place, keeping its position
+	publishHeld(t, &topic, s, event{"a", 1})
+	topic.Publish(event{"b", 1})
+	topic.Publish(event{"c", 1})
+	topic.Publish(event{"b", 2})
+	if s.Coalesced() != 1 || s.Dropped() != 0 {
+		t.Errorf("coalesced = %d, dropped = %d, want 1 and 0", s.Coalesced(), s.Dropped())
+	}
+
+	// A new key on a full buffer drops the oldest
+	topic.Publish(event{"d", 1})
+	receive(t, s, event{"a", 1}, event{"c", 1}, event{"d", 1})
+	if s.Dropped() != 1 {
+		t.Errorf("dropped = %d, want 1", s.Dropped())
+	}
+
+	// Without a key Coalesce falls back to DropOldest
+	var plain Topic[int]
+	fallback := plain.Subscribe("test", 1, Coalesce, nil)
+	defer fallback.Unsubscribe()
+	publishHeld(t, &plain, fallback, 1)
+	plain.Publish(2)
+	plain.Publish(3)
+	receive(t, fallback, 1, 3)
+	if fallback.Dropped() != 1 || fallback.Coalesced() != 0 {
+		t.Errorf("fallback: dropped = %d, coalesced = %d, want 1 and 0", fallback.Dropped(), fallback.Coalesced())
+	}
+}
+
+func TestBusBlock(t *testing.T) {
+	var topic Topic[int]
+	s := topic.Subscribe("test", 1, Block, nil)
+
+	publishHeld(t, &topic, s, 1)
+	topic.Publish(2)
+
+	published := make(chan struct{})
+	go func() {
+		topic.Publish(3)
+		close(published)
+	}()
+	select {
+	case <-published:
+		t.Fatal("Publish returned with a full buffer")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	receive(t, s, 1, 2, 3)
+	<-published
+	if s.Dropped() != 0 {
+		t.Errorf("dropped = %d, want 0", s.Dropped())
+	}
+
+	// Unsubscribe releases a blocked publisher and closes C
+	publishHeld(t, &topic, s, 4)
+	topic.Publish(5)
+	released := make(chan struct{})
+	go func() {
+		topic.Publish(6)
+		close(released)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	s.Unsubscribe()
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Fatal("Unsubscribe did not release the publisher")
+	}
+	for range s.C {
+	}
+}
+
+func TestBusFanOut(t *testing.T) {
+	var topic Topic[int]
+	first := topic.Subscribe("first", 4, DropOldest, nil)
+	second := topic.Subscribe("second", 4, DropOldest, nil)
+	defer second.Unsubscribe()
+
+	topic.Publish(1)
+	receive(t, first, 1)
+	receive(t, second, 1)
+
+	// An unsubscribed consumer no longer receives events
+	first.Unsubscribe()
+	topic.Publish(2)
+	receive(t, second, 2)
+	if _, open := <-first.C; open {
+		t.Error("C still open after Unsubscribe")
+	}
+}
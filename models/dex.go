@@ -1,9 +1,14 @@
 package models
 
 import (
+	"context"
+
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
+// DEXInstance watches one pool and publishes its parsed events on eventBus until ctx is cancelled.
+// Failed subscriptions and bootstrap calls are retried with a backoff; a pool that cannot be watched as configured
+// (bad address, token order) is logged and WatchPairSwaps returns, leaving the other pools running.
 type DEXInstance interface {
-	WatchPairSwaps(ethClient *ethclient.Client, pool *Pool, universalChan chan<- EventData)
+	WatchPairSwaps(ctx context.Context, ethClient *ethclient.Client, pool *Pool, eventBus *Bus)
 }
@@ -1,18 +1,115 @@
 package models
 
 import (
+	"fmt"
 	"math/big"
 	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 type EventData struct {
 	DEXSymbol               string
 	PoolAddress             string
+	PoolKey                 string // Key of the pool in the PoolList (see Pool.Key)
 	BlockNumber             uint64
 	Latency                 time.Duration
 	Fee                     *big.Int
 	Token0Symbol            string
 	Token1Symbol            string
-	Token0ToToken1AmountOut *big.Float
-	Token1ToToken0AmountOut *big.Float
+	Token0ToToken1AmountOut *Amount // Token1 received for one whole token0, rounded like the pool
+	Token1ToToken0AmountOut *Amount // Token0 received for one whole token1, rounded like the pool
+	Quoter                  Quoter  // Exact-input and exact-output quotes at the pool state after the event
+
+	// Raw swap fields, nil/zero when the DEX does not emit them or the event was not a swap
+	Amount0      *big.Int       // Raw token0 amount, pool perspective: positive into the pool, negative out of it
+	Amount1      *big.Int       // Raw token1 amount, same convention as Amount0
+	SqrtPriceX96 *big.Int       // sqrt(token1/token0) as Q64.96 after the swap (Algebra's Price is the same value)
+	Liquidity    *big.Int       // In-range liquidity after the swap
+	Tick         *big.Int       // Current tick after the swap
+	Sender       common.Address // Address that called the pool
+	Recipient    common.Address // Address that received the output
+	Reserve0     *big.Int       // Raw token0 reserve after the event (virtual reserve for concentrated liquidity), nil if not modelled
+	Reserve1     *big.Int       // Raw token1 reserve, same convention as Reserve0
+
+	// Log identity
+	BlockHash common.Hash
+	TxHash    common.Hash
+	TxIndex   uint
+	LogIndex  uint
+}
+
+// SetLog copies the block and transaction identity of the log that produced the event.
+func (e *EventData) SetLog(vLog types.Log) {
+	e.BlockNumber = vLog.BlockNumber
+	e.BlockHash = vLog.BlockHash
+	e.TxHash = vLog.TxHash
+	e.TxIndex = vLog.TxIndex
+	e.LogIndex = vLog.Index
+}
+
+// LogID identifies the log that produced the event, the same across providers.
+// The block hash is included so that a log re-included after a reorg is a new event.
+func (e EventData) LogID() string {
+	return fmt.Sprintf("%v:%v:%v", e.BlockHash.Hex(), e.TxHash.Hex(), e.LogIndex)
+}
+
+// Before reports whether e was emitted before other in chain order (block, transaction, log).
+func (e EventData) Before(other EventData) bool {
+	if e.BlockNumber != other.BlockNumber {
+		return e.BlockNumber < other.BlockNumber
+	}
+	if e.TxIndex != other.TxIndex {
+		return e.TxIndex < other.TxIndex
+	}
+	return e.LogIndex < other.LogIndex
+}
+
+// LiquidityEvent is liquidity added to (Mint) or removed from (Burn) a pool.
+// Amounts are raw token amounts of the pool edge; Liquidity is set instead for concentrated liquidity pools.
+type LiquidityEvent struct {
+	DEXSymbol   string
+	PoolAddress string
+	PoolKey     string
+	BlockNumber uint64
+	Amount0     *big.Int
+	Amount1     *big.Int
+	Liquidity   *big.Int
+}
+
+// FeeChangeEvent reports a new swap fee, in the same units as EventData.Fee.
+type FeeChangeEvent struct {
+	DEXSymbol   string
+	PoolAddress string
+	PoolKey     string
+	BlockNumber uint64
+	Fee         *big.Int
+}
+
+// HeadEvent is a new block header of the chain.
+type HeadEvent struct {
+	BlockNumber uint64
+	Hash        common.Hash
+	ParentHash  common.Hash
+	Time        uint64
+}
+
+// ReorgEvent reports that blocks from BlockNumber on were replaced.
+type ReorgEvent struct {
+	BlockNumber uint64      // First replaced block
+	OldHash     common.Hash // Hash previously seen at BlockNumber
+	NewHash     common.Hash // Hash now at BlockNumber
+}
+
+// OpportunityEvent is a cycle whose cumulative exchange rate exceeded the strategy threshold.
+type OpportunityEvent struct {
+	Tokens          []string // Token symbols along the cycle, starting and ending with the same token
+	PoolKeys        []string // One pool per hop
+	Multiplier      *big.Float
+	SnapshotVersion uint64
+	BlockNumber     uint64
+	AmountIn        *Amount  // Optimal input in Tokens[0], nil when not sized
+	Profit          *Amount  // Expected profit in Tokens[0] at AmountIn, nil when not sized
+	Suspicious      []string // Pool keys whose spot price strays from their TWAP (see strategy.ScreenOpportunities)
 }
@@ -4,33 +4,65 @@ import (
 	"errors"
 	"math/big"
 	"sync"
+	"sync/atomic"
 )
 
 // NOTE: pointing to Token so that we can modify token balances and see that reflected from a PoolList search
 type Pool struct {
 	Address                 string
+	ID                      string // Optional identifier for pools whose Address is not unique (see Key)
 	DEX                     string
 	RouterContractAddress   string
-	Fee                     *big.Int
+	Fee                     *big.Int // As configured, then as of the last event for DEXes with dynamic fees (see EventData.Fee)
 	Token0                  *Token
 	Token1                  *Token
-	Token0ToToken1AmountOut *big.Float
-	Token1ToToken0AmountOut *big.Float
+	TickSpacing             int      // Uniswap V4 only: part of the PoolKey
+	Hooks                   string   // Uniswap V4 only: hooks contract of the PoolKey (zero address for none)
+	BlockNumber             uint64   // Block of the event that last updated the amount outs
+	Reserve0                *big.Int // Raw (virtual) token0 reserve, nil if the DEX adapter does not model it
+	Reserve1                *big.Int // Raw (virtual) token1 reserve, nil if the DEX adapter does not model it
+	Token0ToToken1AmountOut *Amount  // Token1 received for one whole Token0, nil until the first event
+	Token1ToToken0AmountOut *Amount  // Token0 received for one whole Token1, nil until the first event
+	Quoter                  Quoter   // Exact quotes at the pool's last known state, nil until the first event
 }
 
+// Key returns the identifier a pool is stored under in a PoolList: its ID if set, otherwise its Address.
+func (p *Pool) Key() string {
+	if p.ID != "" {
+		return p.ID
+	}
+	return p.Address
+}
+
+// EdgeID builds the ID of one token pair of a multi-asset pool (e.g. Curve, Balancer).
+// Such pools are added once per token pair, all sharing the same Address.
+func EdgeID(address string, token0, token1 *Token) string {
+	return address + ":" + token0.Symbol + "/" + token1.Symbol
+}
+
+// ErrStaleUpdate rejects an update older than the state it would replace.
+var ErrStaleUpdate = errors.New("update is older than the pool state")
+
 // PoolList manages a collection of Pools with efficient access methods.
+// Reads go through immutable PoolSnapshots; every write copies the pool it changes and publishes a new snapshot (copy-on-write).
 type PoolList struct {
-	symbolMap  map[string]*Pool
-	addressMap map[string]*Pool
-	mutex      sync.RWMutex
+	current atomic.Pointer[PoolSnapshot]
+	mutex   sync.Mutex // serializes writers
+}
+
+// PoolSnapshot is an immutable, versioned view of every pool in a PoolList.
+// Pools reachable from a snapshot are never modified, so a strategy can evaluate a whole cycle against one consistent set of prices.
+type PoolSnapshot struct {
+	Version     uint64 // Incremented on every write to the PoolList
+	BlockNumber uint64 // Highest block number of any event applied so far
+	keyMap      map[string]*Pool
 }
 
 // NewPoolList initializes and returns a new PoolList.
 func NewPoolList() *PoolList {
-	return &PoolList{
-		symbolMap:  make(map[string]*Pool),
-		addressMap: make(map[string]*Pool),
-	}
+	pl := &PoolList{}
+	pl.current.Store(&PoolSnapshot{keyMap: make(map[string]*Pool)})
+	return pl
 }
 
 // NewPoolListFromSlice initializes and returns a new PoolList populated with pools from a slice.
@@ -39,134 +71,220 @@ func NewPoolListFromSlice(pools []*Pool) (*PoolList, error) {
 	pl := NewPoolList()
 	pl.mutex.Lock()
 	defer pl.mutex.Unlock()
+
+	keyMap := make(map[string]*Pool, len(pools))
 	for _, pool := range pools {
-		if _, exists := pl.addressMap[pool.Address]; exists {
-			return nil, errors.New("duplicate pool address")
+		if _, exists := keyMap[pool.Key()]; exists {
+			return nil, errors.New("duplicate pool key")
 		}
 
-		pl.addressMap[pool.Address] = pool
+		keyMap[pool.Key()] = pool
 	}
+	pl.current.Store(&PoolSnapshot{keyMap: keyMap})
 	return pl, nil
 }
 
+// Snapshot returns the latest published snapshot of the PoolList.
+// The snapshot stays valid and unchanged while later updates are applied.
+func (pl *PoolList) Snapshot() *PoolSnapshot {
+	return pl.current.Load()
+}
+
+// publish copies the latest snapshot, applies modify to the copied map and stores the result as the new snapshot.
+// Callers must hold pl.mutex.
+func (pl *PoolList) publish(blockNumber uint64, modify func(keyMap map[string]*Pool) error) error {
+	prev := pl.current.Load()
+
+	keyMap := make(map[string]*Pool, len(prev.keyMap)+1)
+	for key, pool := range prev.keyMap {
+		keyMap[key] = pool
+	}
+	if err := modify(keyMap); err != nil {
+		return err
+	}
+
+	next := &PoolSnapshot{
+		Version:     prev.Version + 1,
+		BlockNumber: prev.BlockNumber,
+		keyMap:      keyMap,
+	}
+	if blockNumber > next.BlockNumber {
+		next.BlockNumber = blockNumber
+	}
+	pl.current.Store(next)
+	return nil
+}
+
 // AddPool adds a new pool to the PoolList.
-// It returns an error if a pool with the same address already exists.
+// It returns an error if a pool with the same key already exists.
 func (pl *PoolList) AddPool(pool Pool) error {
 	pl.mutex.Lock()
 	defer pl.mutex.Unlock()
 
-	if _, exists := pl.addressMap[pool.Address]; exists {
-		return errors.New("pool with this address already exists")
-	}
+	return pl.publish(0, func(keyMap map[string]*Pool) error {
+		if _, exists := keyMap[pool.Key()]; exists {
+			return errors.New("pool with this key already exists")
+		}
 
-	// Create a copy to store pointers in maps
-	p := pool
-	pl.addressMap[pool.Address] = &p
+		// Create a copy to store pointers in maps
+		p := pool
+		keyMap[pool.Key()] = &p
+		return nil
+	})
+}
 
-	return nil
+// GetPoolByKey retrieves a pool by its key (see Pool.Key).
+// Returns an error if the pool is not found.
+func (pl *PoolList) GetPoolByKey(key string) (*Pool, error) {
+	return pl.Snapshot().GetPoolByKey(key)
 }
 
 // GetPoolByAddress retrieves a pool by its address.
 // Returns an error if the pool is not found.
 func (pl *PoolList) GetPoolByAddress(address string) (*Pool, error) {
-	pl.mutex.RLock()
-	defer pl.mutex.RUnlock()
-
-	pool, exists := pl.addressMap[address]
-	if !exists {
-		return nil, errors.New("no pool found with the given address")
-	}
-	return pool, nil
+	return pl.Snapshot().GetPoolByAddress(address)
 }
 
 // GetPoolByTokenAndDexSymbols retrieves a pool by the symbols of its token and the symbol of its dex.
 // Returns an error if the pool is not found.
 func (pl *PoolList) GetPoolByTokenAndDexSymbols(symbol0, symbol1, dex string) (*Pool, error) {
-	pl.mutex.RLock()
-	defer pl.mutex.RUnlock()
+	return pl.Snapshot().GetPoolByTokenAndDexSymbols(symbol0, symbol1, dex)
+}
 
-	allPools := pl.ListPools()
-	for _, pool := range allPools {
-		if pool.DEX != dex {
-			continue
-		}
+// RemovePoolByKey removes a pool from the PoolList by its key (see Pool.Key).
+// Returns an error if the pool is not found.
+func (pl *PoolList) RemovePoolByKey(key string) error {
+	pl.mutex.Lock()
+	defer pl.mutex.Unlock()
 
-		if (pool.Token0.Symbol == symbol0 && pool.Token1.Symbol == symbol1) || (pool.Token0.Symbol == symbol1 && pool.Token1.Symbol == symbol0) {
-			return pool, nil
+	return pl.publish(0, func(keyMap map[string]*Pool) error {
+		if _, exists := keyMap[key]; !exists {
+			return errors.New("no pool found with the given key")
 		}
-	}
 
-	return nil, errors.New("not pool with given token symbols and dex symbol could be found")
+		delete(keyMap, key)
+		return nil
+	})
+}
+
+// ListPools returns a slice of all pools in the PoolList.
+func (pl *PoolList) ListPools() []*Pool {
+	return pl.Snapshot().ListPools()
 }
 
-// RemovePoolByAddress removes a pool from the PoolList by its address.
-// Returns an error if the token is not found.
-func (pl *PoolList) RemovePoolByAddress(address string) error {
+// UpdatePoolAmountOutsByKey takes in a key (to specify a pool), the block number of the triggering event, the fee it was quoted with (nil to keep the pool's), then token0ToToken1AmountOut, token1ToToken0AmountOut, the reserves and the quoter (nil if unknown) to update for that pool.
+// The pool is copied rather than modified in place, so earlier snapshots keep their prices.
+// Events from a block before the pool's last update arrive late and are rejected with ErrStaleUpdate.
+func (pl *PoolList) UpdatePoolAmountOutsByKey(key string, blockNumber uint64, fee *big.Int, token0ToToken1AmountOut, token1ToToken0AmountOut *Amount, reserve0, reserve1 *big.Int, quoter Quoter) error {
 	pl.mutex.Lock()
 	defer pl.mutex.Unlock()
 
-	pool, exists := pl.addressMap[address]
-	if !exists {
-		return errors.New("no pool found with the given symbol")
-	}
+	return pl.publish(blockNumber, func(keyMap map[string]*Pool) error {
+		pool, exists := keyMap[key]
+		if !exists {
+			return errors.New("no pool found with the given key")
+		}
+		if blockNumber < pool.BlockNumber {
+			return ErrStaleUpdate
+		}
 
-	delete(pl.addressMap, pool.Address)
-	return nil
+		updated := *pool
+		updated.BlockNumber = blockNumber
+		if fee != nil {
+			updated.Fee = fee
+		}
+		updated.Token0ToToken1AmountOut = token0ToToken1AmountOut
+		updated.Token1ToToken0AmountOut = token1ToToken0AmountOut
+		updated.Reserve0 = reserve0
+		updated.Reserve1 = reserve1
+		updated.Quoter = quoter
+		keyMap[key] = &updated
+		return nil
+	})
 }
 
-// ListPools returns a slice of all pools in the PoolList.
-func (pl *PoolList) ListPools() []*Pool {
-	pl.mutex.RLock()
-	defer pl.mutex.RUnlock()
+// GetBestPoolForTokens finds the best pool to convert from one token to another.
+// It returns the pool that provides the maximum amount out for the given token pair.
+func (pl *PoolList) GetBestPoolForTokens(fromTokenSymbol, toTokenSymbol string) (*Pool, error) {
+	return pl.Snapshot().GetBestPoolForTokens(fromTokenSymbol, toTokenSymbol)
+}
 
-	pools := make([]*Pool, 0, len(pl.addressMap))
-	for _, pool := range pl.addressMap {
-		pools = append(pools, pool)
+// GetPoolByAddress retrieves a pool by its address.
+// For multi-asset pools the first matching token pair is returned.
+// Returns an error if the pool is not found.
+func (ps *PoolSnapshot) GetPoolByAddress(address string) (*Pool, error) {
+	if pool, exists := ps.keyMap[address]; exists {
+		return pool, nil
 	}
-	return pools
+	for _, pool := range ps.keyMap {
+		if pool.Address == address {
+			return pool, nil
+		}
+	}
+	return nil, errors.New("no pool found with the given address")
 }
 
-// UpdatePoolAmountOutsByAddress takes in an address (to specify a pool) then token0ToToken1AmountOut, token1ToToken0AmountOut to update for that pool.
-func (pl *PoolList) UpdatePoolAmountOutsByAddress(address string, token0ToToken1AmountOut, token1ToToken0AmountOut *big.Float) error {
-	pl.mutex.Lock()
-	defer pl.mutex.Unlock()
-
-	pool, exists := pl.addressMap[address]
+// GetPoolByKey retrieves a pool by its key (see Pool.Key).
+// Returns an error if the pool is not found.
+func (ps *PoolSnapshot) GetPoolByKey(key string) (*Pool, error) {
+	pool, exists := ps.keyMap[key]
 	if !exists {
-		return errors.New("no pool found with the given address")
+		return nil, errors.New("no pool found with the given key")
 	}
-	pool.Token0ToToken1AmountOut = token0ToToken1AmountOut
-	pool.Token1ToToken0AmountOut = token1ToToken0AmountOut
-	return nil
+	return pool, nil
+}
+
+// GetPoolByTokenAndDexSymbols retrieves a pool by the symbols of its token and the symbol of its dex.
+// Returns an error if the pool is not found.
+func (ps *PoolSnapshot) GetPoolByTokenAndDexSymbols(symbol0, symbol1, dex string) (*Pool, error) {
+	for _, pool := range ps.keyMap {
+		if pool.DEX != dex {
+			continue
+		}
+
+		if (pool.Token0.Symbol == symbol0 && pool.Token1.Symbol == symbol1) || (pool.Token0.Symbol == symbol1 && pool.Token1.Symbol == symbol0) {
+			return pool, nil
+		}
+	}
+
+	return nil, errors.New("not pool with given token symbols and dex symbol could be found")
+}
+
+// ListPools returns a slice of all pools in the snapshot.
+func (ps *PoolSnapshot) ListPools() []*Pool {
+	pools := make([]*Pool, 0, len(ps.keyMap))
+	for _, pool := range ps.keyMap {
+		pools = append(pools, pool)
+	}
+	return pools
 }
 
 // GetBestPoolForTokens finds the best pool to convert from one token to another.
 // It returns the pool that provides the maximum amount out for the given token pair.
-func (pl *PoolList) GetBestPoolForTokens(fromTokenSymbol, toTokenSymbol string) (*Pool, error) {
-	pl.mutex.RLock()
-	defer pl.mutex.RUnlock()
-
+func (ps *PoolSnapshot) GetBestPoolForTokens(fromTokenSymbol, toTokenSymbol string) (*Pool, error) {
 	var bestPool *Pool
-	maxAmountOut := big.NewFloat(0)
+	var maxAmountOut *Amount
 
-	for _, pool := range pl.addressMap {
+	for _, pool := range ps.keyMap {
 		if pool.Token0ToToken1AmountOut == nil || pool.Token1ToToken0AmountOut == nil {
 			continue
 		}
+		var amountOut *Amount
 		if pool.Token0.Symbol == fromTokenSymbol && pool.Token1.Symbol == toTokenSymbol {
 			// Direction is Token0 to Token1
-			amountOut := pool.Token0ToToken1AmountOut
-			if amountOut.Cmp(maxAmountOut) > 0 {
-				maxAmountOut = amountOut
-				bestPool = pool
-			}
+			amountOut = pool.Token0ToToken1AmountOut
 		} else if pool.Token1.Symbol == fromTokenSymbol && pool.Token0.Symbol == toTokenSymbol {
 			// Direction is Token1 to Token0
-			amountOut := pool.Token1ToToken0AmountOut
-			if amountOut.Cmp(maxAmountOut) > 0 {
-				maxAmountOut = amountOut
-				bestPool = pool
-			}
+			amountOut = pool.Token1ToToken0AmountOut
+		} else {
+			continue
+		}
+		if maxAmountOut == nil {
+			maxAmountOut, bestPool = amountOut, pool
+			continue
+		}
+		if cmp, err := amountOut.Cmp(maxAmountOut); err == nil && cmp > 0 {
+			maxAmountOut, bestPool = amountOut, pool
 		}
 	}
 
@@ -177,9 +295,9 @@ func (pl *PoolList) GetBestPoolForTokens(fromTokenSymbol, toTokenSymbol string)
 	return bestPool, nil
 }
 
-// AmountOutFromToken returns the amount out when swapping from the given token.
+// AmountOutFromToken returns the amount out when swapping one whole unit of the given token.
 // If the token is not part of the pool, it returns an error.
-func (p *Pool) AmountOutFromToken(tokenSymbol string) (*big.Float, error) {
+func (p *Pool) AmountOutFromToken(tokenSymbol string) (*Amount, error) {
 	if p.Token0.Symbol == tokenSymbol {
 		return p.Token0ToToken1AmountOut, nil
 	} else if p.Token1.Symbol == tokenSymbol {
@@ -189,9 +307,9 @@ func (p *Pool) AmountOutFromToken(tokenSymbol string) (*big.Float, error) {
 	}
 }
 
-// AmountOutToToken returns the amount out when swapping to the given token.
+// AmountOutToToken returns the amount of the given token received for one whole unit of the other token.
 // If the token is not part of the pool, it returns an error.
-func (p *Pool) AmountOutToToken(tokenSymbol string) (*big.Float, error) {
+func (p *Pool) AmountOutToToken(tokenSymbol string) (*Amount, error) {
 	if p.Token0.Symbol == tokenSymbol {
 		// Swapping to Token0: amount out is Token1ToToken0AmountOut
 		return p.Token1ToToken0AmountOut, nil
@@ -202,3 +320,13 @@ func (p *Pool) AmountOutToToken(tokenSymbol string) (*big.Float, error) {
 		return nil, errors.New("token not found in pool")
 	}
 }
+
+// RateFromToken returns the whole tokens received per whole unit of the given token, nil if the pool is not priced yet.
+// If the token is not part of the pool, it returns an error.
+func (p *Pool) RateFromToken(tokenSymbol string) (*big.Float, error) {
+	amountOut, err := p.AmountOutFromToken(tokenSymbol)
+	if err != nil || amountOut == nil {
+		return nil, err
+	}
+	return amountOut.Float(), nil
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestUpdatePoolCarriesTheFee(t *testing.T) {
+	configured := &Pool{Address: "0x01", DEX: "QuickswapV3", Fee: big.NewInt(500), Token0: testUSDC, Token1: testWETH}
+	poolList, err := NewPoolListFromSlice([]*Pool{configured})
+	if err != nil {
+		t.Fatal(err)
+	}
+	feeOf := func() int64 {
+		t.Helper()
+		pool, err := poolList.GetPoolByKey("0x01")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pool.Fee.Int64()
+	}
+
+	// An Algebra pool's dynamic fee moved from the configured 500 to 2000
+	before := poolList.Snapshot()
+	if err := poolList.UpdatePoolAmountOutsByKey("0x01", 10, big.NewInt(2000), nil, nil, nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if fee := feeOf(); fee != 2000 {
+		t.Errorf("Fee = %v after the update, want 2000", fee)
+	}
+	if pool, _ := before.GetPoolByKey("0x01"); pool.Fee.Int64() != 500 || configured.Fee.Int64() != 500 {
+		t.Errorf("the update changed the earlier snapshot's fee to %v or the configured one to %v", pool.Fee, configured.Fee)
+	}
+
+	// A nil fee keeps the last known one
+	if err := poolList.UpdatePoolAmountOutsByKey("0x01", 11, nil, nil, nil, nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if fee := feeOf(); fee != 2000 {
+		t.Errorf("Fee = %v after an update without fee, want 2000", fee)
+	}
+
+	// A late event does not bring back an older fee
+	if err := poolList.UpdatePoolAmountOutsByKey("0x01", 9, big.NewInt(100), nil, nil, nil, nil, nil); !errors.Is(err, ErrStaleUpdate) {
+		t.Errorf("stale update error = %v, want %v", err, ErrStaleUpdate)
+	}
+	if fee := feeOf(); fee != 2000 {
+		t.Errorf("Fee = %v after a stale update, want 2000", fee)
+	}
+}
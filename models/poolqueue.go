@@ -0,0 +1,92 @@
+package models
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// PoolQueue keeps only the latest swap event of every pool until the strategy loop drains it.
+// Push never blocks, so watchers keep up during bursts; a burst on one pool collapses into a single dirty entry.
+type PoolQueue struct {
+	latest    map[string]EventData // Latest event by pool key, for dirty pools only
+	ready     chan struct{}        // Signalled when the dirty set becomes non-empty
+	mutex     sync.Mutex
+	received  atomic.Uint64
+	coalesced atomic.Uint64
+	drained   atomic.Uint64
+}
+
+// PoolQueueStats are the counters of a PoolQueue since its creation.
+type PoolQueueStats struct {
+	Received  uint64 // Events pushed
+	Coalesced uint64 // Events replaced by a later event of the same pool before being drained
+	Drained   uint64 // Events handed to the strategy loop
+}
+
+// NewPoolQueue initializes and returns an empty PoolQueue.
+func NewPoolQueue() *PoolQueue {
+	return &PoolQueue{
+		latest: make(map[string]EventData),
+		ready:  make(chan struct{}, 1),
+	}
+}
+
+// Push records event as the latest state of its pool.
+// An event older (in chain order) than the one already pending for the pool is discarded.
+func (q *PoolQueue) Push(event EventData) {
+	q.received.Add(1)
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if pending, exists := q.latest[event.PoolKey]; exists {
+		q.coalesced.Add(1)
+		if event.Before(pending) {
+			return
+		}
+	}
+	q.latest[event.PoolKey] = event
+
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// Ready is signalled when pools are waiting to be drained.
+func (q *PoolQueue) Ready() <-chan struct{} {
+	return q.ready
+}
+
+// Drain returns the latest event of every dirty pool in chain order and clears the dirty set.
+func (q *PoolQueue) Drain() []EventData {
+	q.mutex.Lock()
+	events := make([]EventData, 0, len(q.latest))
+	for _, event := range q.latest {
+		events = append(events, event)
+	}
+	q.latest = make(map[string]EventData)
+	q.mutex.Unlock()
+
+	sort.Slice(events, func(a, b int) bool {
+		return events[a].Before(events[b])
+	})
+	q.drained.Add(uint64(len(events)))
+	return events
+}
+
+// Dirty returns the number of pools waiting to be drained.
+func (q *PoolQueue) Dirty() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return len(q.latest)
+}
+
+// Stats returns the counters of the queue.
+func (q *PoolQueue) Stats() PoolQueueStats {
+	return PoolQueueStats{
+		Received:  q.received.Load(),
+		Coalesced: q.coalesced.Load(),
+		Drained:   q.drained.Load(),
+	}
+}
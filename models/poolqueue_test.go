@@ -0,0 +1,42 @@
+package models
+
+import "testing"
+
+func queuedEvent(poolKey string, blockNumber uint64, logIndex uint) EventData {
+	return EventData{PoolKey: poolKey, BlockNumber: blockNumber, LogIndex: logIndex}
+}
+
+func TestPoolQueueCoalesces(t *testing.T) {
+	q := NewPoolQueue()
+	q.Push(queuedEvent("a", 10, 0))
+	q.Push(queuedEvent("a", 10, 3))
+	q.Push(queuedEvent("a", 10, 1)) // Older than the pending event, discarded
+	q.Push(queuedEvent("b", 9, 0))
+
+	select {
+	case <-q.Ready():
+	default:
+		t.Fatal("Ready not signalled")
+	}
+	if q.Dirty() != 2 {
+		t.Errorf("dirty = %d, want 2", q.Dirty())
+	}
+
+	// The latest event of each pool, in chain order
+	events := q.Drain()
+	if len(events) != 2 || events[0].PoolKey != "b" || events[1].PoolKey != "a" || events[1].LogIndex != 3 {
+		t.Fatalf("drained %+v", events)
+	}
+	if q.Dirty() != 0 {
+		t.Errorf("dirty after drain = %d, want 0", q.Dirty())
+	}
+	if stats := q.Stats(); stats != (PoolQueueStats{Received: 4, Coalesced: 2, Drained: 2}) {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	// A pool pushed after a drain is dirty again
+	q.Push(queuedEvent("a", 11, 0))
+	if events := q.Drain(); len(events) != 1 || events[0].BlockNumber != 11 {
+		t.Errorf("drained %+v after a new push", events)
+	}
+}
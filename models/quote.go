@@ -0,0 +1,79 @@
+package models
+
+import (
+	"errors"
+)
+
+// Quoter prices swaps against the state of a pool as of the event that produced it.
+// Implementations are immutable once published, so a snapshot can be quoted while newer events arrive.
+type Quoter interface {
+	// AmountOut is the exact output received for an exact input (exactInput).
+	AmountOut(amountIn *Amount, tokenOut *Token) (*Amount, error)
+	// AmountIn is the input needed to receive exactly amountOut (exactOutput); it is rounded so that the pool pays at least amountOut.
+	AmountIn(amountOut *Amount, tokenIn *Token) (*Amount, error)
+}
+
+var (
+	ErrNoQuoter              = errors.New("pool has no quoter")
+	ErrInsufficientLiquidity = errors.New("not enough liquidity for the amount")
+	ErrInvalidPath           = errors.New("path tokens do not match its pools")
+)
+
+// QuoteExactInputPath swaps amountIn through pools, hop k going from tokens[k] to tokens[k+1].
+// Returns the amount held after every hop, amounts[0] being amountIn.
+func QuoteExactInputPath(pools []*Pool, tokens []*Token, amountIn *Amount) ([]*Amount, error) {
+	if err := checkPath(pools, tokens); err != nil {
+		return nil, err
+	}
+	if !SameToken(amountIn.Token, tokens[0]) {
+		return nil, ErrTokenMismatch
+	}
+	amounts := make([]*Amount, len(tokens))
+	amounts[0] = amountIn
+	for k, pool := range pools {
+		amountOut, err := pool.Quoter.AmountOut(amounts[k], tokens[k+1])
+		if err != nil {
+			return nil, err
+		}
+		amounts[k+1] = amountOut
+	}
+	return amounts, nil
+}
+
+// QuoteExactOutputPath is the input needed so that the last hop pays exactly amountOut, resolved from the last hop backwards like exactOutput.
+// Returns the amount held before every hop, amounts[len(tokens)-1] being amountOut.
+func QuoteExactOutputPath(pools []*Pool, tokens []*Token, amountOut *Amount) ([]*Amount, error) {
+	if err := checkPath(pools, tokens); err != nil {
+		return nil, err
+	}
+	if !SameToken(amountOut.Token, tokens[len(tokens)-1]) {
+		return nil, ErrTokenMismatch
+	}
+	amounts := make([]*Amount, len(tokens))
+	amounts[len(tokens)-1] = amountOut
+	for k := len(pools) - 1; k >= 0; k-- {
+		amountIn, err := pools[k].Quoter.AmountIn(amounts[k+1], tokens[k])
+		if err != nil {
+			return nil, err
+		}
+		amounts[k] = amountIn
+	}
+	return amounts, nil
+}
+
+// checkPath verifies every hop's pool trades its two tokens and can be quoted
+func checkPath(pools []*Pool, tokens []*Token) error {
+	if len(pools) == 0 || len(tokens) != len(pools)+1 {
+		return ErrInvalidPath
+	}
+	for k, pool := range pools {
+		if pool.Quoter == nil {
+			return ErrNoQuoter
+		}
+		from, to := tokens[k], tokens[k+1]
+		if !(SameToken(pool.Token0, from) && SameToken(pool.Token1, to)) && !(SameToken(pool.Token1, from) && SameToken(pool.Token0, to)) {
+			return ErrInvalidPath
+		}
+	}
+	return nil
+}
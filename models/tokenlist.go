@@ -0,0 +1,107 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"198/erc20"
+)
+
+// TokenListDocument is a token list in the community token-list format (https://tokenlists.org).
+type TokenListDocument struct {
+	Name   string           `json:"name"`
+	Tokens []TokenListEntry `json:"tokens"`
+}
+
+// TokenListEntry is one token of a token-list document.
+type TokenListEntry struct {
+	ChainID  uint64   `json:"chainId"`
+	Address  string   `json:"address"`
+	Name     string   `json:"name"`
+	Symbol   string   `json:"symbol"`
+	Decimals int      `json:"decimals"`
+	Tags     []string `json:"tags"`
+}
+
+// ParseTokenListJSON returns the tokens of a token-list document deployed on chainID.
+// Tokens carrying any of holdTags are marked as safe to hold.
+func ParseTokenListJSON(data []byte, chainID uint64, holdTags []string) ([]*Token, error) {
+	var document TokenListDocument
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	hold := make(map[string]bool)
+	for _, tag := range holdTags {
+		hold[tag] = true
+	}
+
+	var tokens []*Token
+	for _, entry := range document.Tokens {
+		if entry.ChainID != chainID {
+			continue
+		}
+		if !common.IsHexAddress(entry.Address) {
+			return nil, fmt.Errorf("token %v: invalid address %q", entry.Symbol, entry.Address)
+		}
+		token := &Token{
+			Symbol:   entry.Symbol,
+			Address:  entry.Address,
+			Decimals: entry.Decimals,
+		}
+		for _, tag := range entry.Tags {
+			if hold[tag] {
+				token.Hold = true
+			}
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+// NewTokenListFromTokenListJSON initializes a TokenList from a token-list document, keeping the tokens deployed on chainID.
+// Returns an error if the document is invalid or holds duplicate symbols or addresses for that chain.
+func NewTokenListFromTokenListJSON(data []byte, chainID uint64, holdTags []string) (*TokenList, error) {
+	tokens, err := ParseTokenListJSON(data, chainID, holdTags)
+	if err != nil {
+		return nil, err
+	}
+	return NewTokenListFromSlice(tokens)
+}
+
+// VerifyTokens cross-checks tokens against their erc20 contracts.
+// A decimals mismatch is an error; a different on-chain symbol (e.g. bridged "USDC.e") is only logged.
+func VerifyTokens(ethClient *ethclient.Client, tokens []*Token) error {
+	opts := &bind.CallOpts{Context: context.Background()}
+	for _, token := range tokens {
+		tokenContract, err := erc20.NewErc20(common.HexToAddress(token.Address), ethClient)
+		if err != nil {
+			return err
+		}
+
+		decimals, err := tokenContract.Decimals(opts)
+		if err != nil {
+			return fmt.Errorf("token %v: failed to read decimals: %w", token.Symbol, err)
+		}
+		if int(decimals) != token.Decimals {
+			return fmt.Errorf("token %v: configured with %v decimals, contract has %v", token.Symbol, token.Decimals, decimals)
+		}
+
+		symbol, err := tokenContract.Symbol(opts)
+		if err != nil {
+			log.Printf("WARNING: token %v: failed to read symbol: %v", token.Symbol, err)
+			continue
+		}
+		if !strings.EqualFold(symbol, token.Symbol) {
+			log.Printf("WARNING: token %v: contract symbol is %v", token.Symbol, symbol)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"198/models"
+)
+
+type hopResponse struct {
+	Pool     string `json:"pool"`
+	DEX      string `json:"dex"`
+	TokenIn  string `json:"tokenIn"`
+	TokenOut string `json:"tokenOut"`
+}
+
+type routeResponse struct {
+	Hops        []hopResponse  `json:"hops"`
+	AmountIn    *models.Amount `json:"amountIn"`
+	AmountOut   *models.Amount `json:"amountOut"`
+	Share       float64        `json:"share"`
+	GasEstimate uint64         `json:"gasEstimate"`
+}
+
+type quoteResponse struct {
+	AmountIn        *models.Amount  `json:"amountIn"`
+	AmountOut       *models.Amount  `json:"amountOut"`
+	Routes          []routeResponse `json:"routes"`
+	PriceImpact     float64         `json:"priceImpact"`
+	GasEstimate     uint64          `json:"gasEstimate"`
+	SnapshotVersion uint64          `json:"snapshotVersion"`
+	BlockNumber     uint64          `json:"blockNumber"`
+}
+
+// Search limits accepted from requests, the search grows combinatorially with hops and linearly with splits
+const (
+	maxRequestHops   = 4
+	maxRequestSplits = 100
+)
+
+// NewHandler serves BestQuote against the latest snapshot of pools:
+// GET ?in=USDC&out=WETH&amount=1000[&hops=3][&splits=20] answers the quote as JSON.
+// hops above 4 and splits above 100 are rejected.
+func NewHandler(pools *models.PoolList, tokens *models.TokenList) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		tokenIn, err := tokens.GetTokenBySymbol(query.Get("in"))
+		if err != nil {
+			http.Error(w, "unknown token in: "+query.Get("in"), http.StatusBadRequest)
+			return
+		}
+		tokenOut, err := tokens.GetTokenBySymbol(query.Get("out"))
+		if err != nil {
+			http.Error(w, "unknown token out: "+query.Get("out"), http.StatusBadRequest)
+			return
+		}
+		amountIn, err := models.ParseAmount(tokenIn, query.Get("amount"))
+		if err != nil {
+			http.Error(w, "invalid amount: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		options := DefaultOptions
+		if query.Has("hops") {
+			hops, err := strconv.Atoi(query.Get("hops"))
+			if err != nil || hops < 1 || hops > maxRequestHops {
+				http.Error(w, "hops must be between 1 and "+strconv.Itoa(maxRequestHops), http.StatusBadRequest)
+				return
+			}
+			options.MaxHops = hops
+		}
+		if query.Has("splits") {
+			splits, err := strconv.Atoi(query.Get("splits"))
+			if err != nil || splits < 1 || splits > maxRequestSplits {
+				http.Error(w, "splits must be between 1 and "+strconv.Itoa(maxRequestSplits), http.StatusBadRequest)
+				return
+			}
+			options.Splits = splits
+		}
+
+		quote, err := BestQuote(pools.Snapshot(), amountIn, tokenOut, options)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(newQuoteResponse(quote))
+	})
+}
+
+func newQuoteResponse(quote *Quote) quoteResponse {
+	response := quoteResponse{
+		AmountIn:        quote.AmountIn,
+		AmountOut:       quote.AmountOut,
+		PriceImpact:     quote.PriceImpact,
+		GasEstimate:     quote.GasEstimate,
+		SnapshotVersion: quote.SnapshotVersion,
+		BlockNumber:     quote.BlockNumber,
+	}
+	for _, route := range quote.Routes {
+		routeResp := routeResponse{AmountIn: route.AmountIn, AmountOut: route.AmountOut, Share: route.Share, GasEstimate: route.GasEstimate}
+		for k, pool := range route.Pools {
+			routeResp.Hops = append(routeResp.Hops, hopResponse{Pool: pool.Key(), DEX: pool.DEX, TokenIn: route.Tokens[k].Symbol, TokenOut: route.Tokens[k+1].Symbol})
+		}
+		response.Routes = append(response.Routes, routeResp)
+	}
+	return response
+}
@@ -0,0 +1,240 @@
+package router
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+
+	"198/dex"
+	"198/models"
+)
+
+// Options bound the route search.
+type Options struct {
+	MaxHops       int // Longest path considered
+	MaxCandidates int // Paths kept (by spot rate) for exact quoting
+	Splits        int // Parts the order is divided into across routes, 1 for a single route
+}
+
+// DefaultOptions searches up to 3 hops and splits in 5% parts.
+var DefaultOptions = Options{MaxHops: 3, MaxCandidates: 12, Splits: 20}
+
+// Route is one path of a quote and the part of the order sent through it.
+type Route struct {
+	Tokens      []*models.Token
+	Pools       []*models.Pool
+	AmountIn    *models.Amount
+	AmountOut   *models.Amount
+	Share       float64 // Fraction of the order's input
+	GasEstimate uint64  // Gas of the route's hops
+}
+
+// Quote is the best split of an order across routes, against one snapshot.
+type Quote struct {
+	AmountIn        *models.Amount
+	AmountOut       *models.Amount
+	Routes          []Route
+	PriceImpact     float64 // Shortfall of AmountOut against the best route's rate for one whole token, 0.01 = 1% (slightly negative for orders below one token)
+	GasEstimate     uint64  // Whole transaction, including dex.TxBaseGas
+	SnapshotVersion uint64
+	BlockNumber     uint64
+}
+
+var ErrNoRoute = errors.New("no priced route between the tokens")
+
+// path is a candidate route and its spot rate (product of the hops' unit quotes)
+type path struct {
+	tokens []*models.Token
+	pools  []*models.Pool
+	rate   *big.Float
+}
+
+// BestQuote searches the paths from amountIn's token to tokenOut across every pool of snapshot,
+// then greedily gives each part of the order to the route with the highest marginal output.
+// Routes sharing a pool are never used together, as each is quoted against the pool's unmodified state.
+func BestQuote(snapshot *models.PoolSnapshot, amountIn *models.Amount, tokenOut *models.Token, options Options) (*Quote, error) {
+	if amountIn.Sign() <= 0 {
+		return nil, errors.New("amount in must be positive")
+	}
+	if options.Splits < 1 {
+		options.Splits = 1
+	}
+	candidates := findPaths(snapshot, amountIn.Token, tokenOut, options)
+	if len(candidates) == 0 {
+		return nil, ErrNoRoute
+	}
+
+	// Input of k parts, rounded down (the remainder goes to the largest route)
+	parts := make([]*models.Amount, options.Splits+1)
+	for k := range parts {
+		raw := new(big.Int).Mul(amountIn.Raw, big.NewInt(int64(k)))
+		parts[k] = models.NewAmount(amountIn.Token, raw.Quo(raw, big.NewInt(int64(options.Splits))))
+	}
+
+	// Output of each candidate for k parts, computed lazily
+	outputs := make([]map[int]*big.Int, len(candidates))
+	output := func(c, k int) *big.Int {
+		if k == 0 {
+			return new(big.Int)
+		}
+		if outputs[c] == nil {
+			outputs[c] = make(map[int]*big.Int)
+		}
+		if out, ok := outputs[c][k]; ok {
+			return out
+		}
+		var out *big.Int
+		if amounts, err := models.QuoteExactInputPath(candidates[c].pools, candidates[c].tokens, parts[k]); err == nil {
+			out = amounts[len(amounts)-1].Raw
+		}
+		outputs[c][k] = out
+		return out
+	}
+
+	allocation := make([]int, len(candidates))
+	for part := 0; part < options.Splits; part++ {
+		best, bestGain := -1, (*big.Int)(nil)
+		for c := range candidates {
+			if allocation[c] == 0 && conflicts(candidates, allocation, c) {
+				continue
+			}
+			next, current := output(c, allocation[c]+1), output(c, allocation[c])
+			if next == nil || current == nil {
+				continue
+			}
+			gain := new(big.Int).Sub(next, current)
+			if bestGain == nil || gain.Cmp(bestGain) > 0 {
+				best, bestGain = c, gain
+			}
+		}
+		if best < 0 {
+			return nil, ErrNoRoute
+		}
+		allocation[best]++
+	}
+
+	quote := &Quote{
+		AmountIn:        amountIn,
+		AmountOut:       models.NewAmount(tokenOut, new(big.Int)),
+		GasEstimate:     dex.TxBaseGas,
+		SnapshotVersion: snapshot.Version,
+		BlockNumber:     snapshot.BlockNumber,
+	}
+	for c, k := range allocation {
+		if k == 0 {
+			continue
+		}
+		route := Route{
+			Tokens:      candidates[c].tokens,
+			Pools:       candidates[c].pools,
+			AmountIn:    parts[k],
+			AmountOut:   models.NewAmount(tokenOut, output(c, k)),
+			Share:       float64(k) / float64(options.Splits),
+			GasEstimate: routeGas(candidates[c].pools),
+		}
+		quote.Routes = append(quote.Routes, route)
+		quote.AmountOut.Raw.Add(quote.AmountOut.Raw, route.AmountOut.Raw)
+		quote.GasEstimate += route.GasEstimate
+	}
+	sort.Slice(quote.Routes, func(a, b int) bool { return quote.Routes[a].Share > quote.Routes[b].Share })
+
+	remainder := new(big.Int).Set(amountIn.Raw)
+	for _, route := range quote.Routes {
+		remainder.Sub(remainder, route.AmountIn.Raw)
+	}
+	if remainder.Sign() > 0 {
+		largest := &quote.Routes[0]
+		amounts, err := models.QuoteExactInputPath(largest.Pools, largest.Tokens, models.NewAmount(amountIn.Token, new(big.Int).Add(largest.AmountIn.Raw, remainder)))
+		if err != nil {
+			return nil, err
+		}
+		quote.AmountOut.Raw.Add(quote.AmountOut.Raw, new(big.Int).Sub(amounts[len(amounts)-1].Raw, largest.AmountOut.Raw))
+		largest.AmountIn, largest.AmountOut = amounts[0], amounts[len(amounts)-1]
+	}
+
+	// Against the best spot rate: what the order would get without any price impact
+	spotOut := new(big.Float).Mul(amountIn.Float(), candidates[0].rate)
+	if spotOut.Sign() > 0 {
+		ratio, _ := new(big.Float).Quo(quote.AmountOut.Float(), spotOut).Float64()
+		quote.PriceImpact = 1 - ratio
+	}
+	return quote, nil
+}
+
+// findPaths enumerates the simple paths of up to MaxHops pools and keeps the MaxCandidates with the best spot rate
+func findPaths(snapshot *models.PoolSnapshot, tokenIn, tokenOut *models.Token, options Options) []path {
+	byToken := make(map[string][]*models.Pool)
+	for _, pool := range snapshot.ListPools() {
+		if pool.Quoter == nil || pool.Token0ToToken1AmountOut == nil {
+			continue
+		}
+		byToken[pool.Token0.Symbol] = append(byToken[pool.Token0.Symbol], pool)
+		byToken[pool.Token1.Symbol] = append(byToken[pool.Token1.Symbol], pool)
+	}
+
+	var paths []path
+	visited := map[string]bool{tokenIn.Symbol: true}
+	var walk func(current path)
+	walk = func(current path) {
+		from := current.tokens[len(current.tokens)-1]
+		for _, pool := range byToken[from.Symbol] {
+			to := pool.Token1
+			if to.Symbol == from.Symbol {
+				to = pool.Token0
+			}
+			if visited[to.Symbol] {
+				continue
+			}
+			rate, err := pool.RateFromToken(from.Symbol)
+			if err != nil || rate == nil || rate.Sign() <= 0 {
+				continue
+			}
+			next := path{
+				tokens: append(append([]*models.Token(nil), current.tokens...), to),
+				pools:  append(append([]*models.Pool(nil), current.pools...), pool),
+				rate:   new(big.Float).Mul(current.rate, rate),
+			}
+			if to.Symbol == tokenOut.Symbol {
+				paths = append(paths, next)
+				continue
+			}
+			if len(next.pools) < options.MaxHops {
+				visited[to.Symbol] = true
+				walk(next)
+				visited[to.Symbol] = false
+			}
+		}
+	}
+	walk(path{tokens: []*models.Token{tokenIn}, rate: big.NewFloat(1)})
+
+	sort.Slice(paths, func(a, b int) bool { return paths[a].rate.Cmp(paths[b].rate) > 0 })
+	if options.MaxCandidates > 0 && len(paths) > options.MaxCandidates {
+		paths = paths[:options.MaxCandidates]
+	}
+	return paths
+}
+
+// conflicts reports whether candidate c shares a pool with a candidate already allocated
+func conflicts(candidates []path, allocation []int, c int) bool {
+	for other, k := range allocation {
+		if k == 0 || other == c {
+			continue
+		}
+		for _, a := range candidates[c].pools {
+			for _, b := range candidates[other].pools {
+				if a.Key() == b.Key() {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func routeGas(pools []*models.Pool) uint64 {
+	var gas uint64
+	for _, pool := range pools {
+		gas += dex.SwapGas[pool.DEX]
+	}
+	return gas
+}
@@ -0,0 +1,216 @@
+package strategy
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"198/models"
+)
+
+// competitorWindow is the number of blocks closed opportunities are kept around to be linked to a competitor
+const competitorWindow = 64
+
+// CompetitorArbitrage is a transaction that swapped through several of our pools in a closed loop.
+type CompetitorArbitrage struct {
+	TxHash        common.Hash      `json:"txHash"`
+	BlockNumber   uint64           `json:"blockNumber"`
+	TxIndex       uint             `json:"txIndex"`
+	From          common.Address   `json:"from"` // Account that signed the transaction
+	To            common.Address   `json:"to"`   // Contract the transaction called
+	Senders       []common.Address `json:"senders"`
+	Recipients    []common.Address `json:"recipients"`
+	GasPrice      *big.Int         `json:"gasPrice,omitempty"` // Effective gas price paid
+	GasUsed       uint64           `json:"gasUsed,omitempty"`
+	Tokens        []string         `json:"tokens"` // Token path, starting and ending with the same token
+	PoolKeys      []string         `json:"poolKeys"`
+	Profit        *models.Amount   `json:"profit"`                  // Net gain in Tokens[0] before gas
+	Opportunities []string         `json:"opportunities,omitempty"` // Ids of the tracked opportunities it closed
+}
+
+// CompetitorAnalyzer groups swap events by transaction and flags closed loops through our pools as competitor arbitrages.
+// Each is linked to the tracked opportunities closed in its block through one of its pools.
+type CompetitorAnalyzer struct {
+	ethClient *ethclient.Client
+	signer    types.Signer
+	tokenList *models.TokenList
+	pending   map[common.Hash][]models.EventData
+	closed    []*TrackedOpportunity
+	file      *os.File
+	encoder   *json.Encoder
+}
+
+// NewCompetitorAnalyzer opens (or appends to) the JSON lines file at path where competitor arbitrages are recorded.
+func NewCompetitorAnalyzer(ethClient *ethclient.Client, tokenList *models.TokenList, chainID uint64, path string) (*CompetitorAnalyzer, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		return nil, err
+	}
+	return &CompetitorAnalyzer{
+		ethClient: ethClient,
+		signer:    types.LatestSignerForChainID(new(big.Int).SetUint64(chainID)),
+		tokenList: tokenList,
+		pending:   make(map[common.Hash][]models.EventData),
+		file:      file,
+		encoder:   json.NewEncoder(file),
+	}, nil
+}
+
+// Run consumes swaps, new heads and closed opportunities until ctx is cancelled.
+// Transactions are analyzed once their block is two heads old, so the opportunities they closed have been reported.
+func (a *CompetitorAnalyzer) Run(ctx context.Context, swaps <-chan models.EventData, heads <-chan models.HeadEvent, closed <-chan []*TrackedOpportunity) {
+	defer a.file.Close()
+	for {
+		select {
+		case event, ok := <-swaps:
+			if !ok {
+				return
+			}
+			a.AddSwap(event)
+		case opportunities := <-closed:
+			a.closed = append(a.closed, opportunities...)
+		case head := <-heads:
+			if head.BlockNumber < 2 {
+				continue
+			}
+			for _, arbitrage := range a.Flush(head.BlockNumber - 1) {
+				log.Printf("Competitor arbitrage %v in block %v: %v via %v (from: %v) (to: %v) (gas price: %v) (profit: %v) (closed: %v)", arbitrage.TxHash, arbitrage.BlockNumber, arbitrage.Tokens, arbitrage.PoolKeys, arbitrage.From, arbitrage.To, arbitrage.GasPrice, arbitrage.Profit, arbitrage.Opportunities)
+				if err := a.encoder.Encode(arbitrage); err != nil {
+					log.Printf("Failed to persist competitor arbitrage %v: %v", arbitrage.TxHash, err)
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// AddSwap records a swap of one of our pools under its transaction.
+func (a *CompetitorAnalyzer) AddSwap(event models.EventData) {
+	if event.Amount0 == nil || event.Amount1 == nil || event.TxHash == (common.Hash{}) {
+		return
+	}
+	a.pending[event.TxHash] = append(a.pending[event.TxHash], event)
+}
+
+// Flush analyzes the transactions of blocks before beforeBlock and returns the competitor arbitrages among them.
+func (a *CompetitorAnalyzer) Flush(beforeBlock uint64) []CompetitorArbitrage {
+	var arbitrages []CompetitorArbitrage
+	for txHash, swaps := range a.pending {
+		if swaps[0].BlockNumber >= beforeBlock {
+			continue
+		}
+		delete(a.pending, txHash)
+		if arbitrage, ok := a.analyze(swaps); ok {
+			arbitrages = append(arbitrages, arbitrage)
+		}
+	}
+
+	// Forget closed opportunities too old to be linked
+	kept := a.closed[:0]
+	for _, opportunity := range a.closed {
+		if opportunity.ClosedBlock+competitorWindow >= beforeBlock {
+			kept = append(kept, opportunity)
+		}
+	}
+	a.closed = kept
+
+	sort.Slice(arbitrages, func(i, j int) bool {
+		if arbitrages[i].BlockNumber != arbitrages[j].BlockNumber {
+			return arbitrages[i].BlockNumber < arbitrages[j].BlockNumber
+		}
+		return arbitrages[i].TxIndex < arbitrages[j].TxIndex
+	})
+	return arbitrages
+}
+
+// analyze checks whether the swaps of one transaction chain into a loop: each swap's output token is the next one's input, the last output the first input
+func (a *CompetitorAnalyzer) analyze(swaps []models.EventData) (CompetitorArbitrage, bool) {
+	if len(swaps) < 2 {
+		return CompetitorArbitrage{}, false
+	}
+	sort.Slice(swaps, func(i, j int) bool { return swaps[i].LogIndex < swaps[j].LogIndex })
+
+	tokensIn := make([]string, len(swaps))
+	tokensOut := make([]string, len(swaps))
+	amountsIn := make([]*big.Int, len(swaps))
+	amountsOut := make([]*big.Int, len(swaps))
+	for k, swap := range swaps {
+		// Pool perspective: the positive amount went in, the negative one came out
+		switch {
+		case swap.Amount0.Sign() > 0 && swap.Amount1.Sign() < 0:
+			tokensIn[k], tokensOut[k] = swap.Token0Symbol, swap.Token1Symbol
+			amountsIn[k], amountsOut[k] = swap.Amount0, new(big.Int).Neg(swap.Amount1)
+		case swap.Amount1.Sign() > 0 && swap.Amount0.Sign() < 0:
+			tokensIn[k], tokensOut[k] = swap.Token1Symbol, swap.Token0Symbol
+			amountsIn[k], amountsOut[k] = swap.Amount1, new(big.Int).Neg(swap.Amount0)
+		default:
+			return CompetitorArbitrage{}, false
+		}
+	}
+	for k := range swaps {
+		if tokensOut[k] != tokensIn[(k+1)%len(swaps)] {
+			return CompetitorArbitrage{}, false
+		}
+	}
+
+	first := swaps[0]
+	arbitrage := CompetitorArbitrage{
+		TxHash:      first.TxHash,
+		BlockNumber: first.BlockNumber,
+		TxIndex:     first.TxIndex,
+		Tokens:      append(tokensIn, tokensIn[0]),
+	}
+	poolKeys := make(map[string]bool)
+	for _, swap := range swaps {
+		arbitrage.PoolKeys = append(arbitrage.PoolKeys, swap.PoolKey)
+		arbitrage.Senders = append(arbitrage.Senders, swap.Sender)
+		arbitrage.Recipients = append(arbitrage.Recipients, swap.Recipient)
+		poolKeys[swap.PoolKey] = true
+	}
+	if token, err := a.tokenList.GetTokenBySymbol(tokensIn[0]); err == nil {
+		profit := new(big.Int).Sub(amountsOut[len(swaps)-1], amountsIn[0])
+		arbitrage.Profit = models.NewAmount(token, profit)
+	}
+
+	// Winner identity and what it paid for gas
+	ctx := context.Background()
+	if tx, _, err := a.ethClient.TransactionByHash(ctx, first.TxHash); err == nil {
+		if from, err := types.Sender(a.signer, tx); err == nil {
+			arbitrage.From = from
+		}
+		if tx.To() != nil {
+			arbitrage.To = *tx.To()
+		}
+		arbitrage.GasPrice = tx.GasPrice()
+	} else {
+		log.Printf("Failed to fetch transaction %v: %v", first.TxHash, err)
+	}
+	if receipt, err := a.ethClient.TransactionReceipt(ctx, first.TxHash); err == nil {
+		arbitrage.GasUsed = receipt.GasUsed
+		if receipt.EffectiveGasPrice != nil {
+			arbitrage.GasPrice = receipt.EffectiveGasPrice
+		}
+	}
+
+	// Opportunities closed in this block through one of the pools it used
+	for _, opportunity := range a.closed {
+		if opportunity.ClosedBlock != arbitrage.BlockNumber {
+			continue
+		}
+		for _, poolKey := range opportunity.PoolKeys {
+			if poolKeys[poolKey] {
+				arbitrage.Opportunities = append(arbitrage.Opportunities, opportunity.ID)
+				break
+			}
+		}
+	}
+	return arbitrage, true
+}
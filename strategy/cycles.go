@@ -0,0 +1,216 @@
+package strategy
+
+import (
+	"container/heap"
+	"log"
+	"math/big"
+	"sort"
+
+	"198/models"
+)
+
+// Cycle is a triangular path Tokens[0] -> Tokens[1] -> Tokens[2] -> Tokens[0].
+// Pools and Multiplier hold the best pool per hop and the cumulative exchange rate of the last evaluation.
+type Cycle struct {
+	Tokens     [3]string
+	Pools      [3]*models.Pool
+	Multiplier *big.Float // nil while a hop has no priced pool
+	index      int        // Position in the ranking heap, -1 when not ranked
+}
+
+// CycleIndex holds every triangular cycle of a token/pool set, indexed by the pools they can route through.
+// After a swap only the cycles touching the updated pool are re-evaluated; rankings are kept in a max-heap.
+type CycleIndex struct {
+	cycles  []*Cycle
+	byPool  map[string][]*Cycle // Pool key -> cycles with a hop over the pool's token pair
+	byPair  map[string][]string // Pair key -> keys of the pools trading it
+	ranking cycleHeap
+}
+
+// pairKey identifies an unordered token pair
+func pairKey(symbol0, symbol1 string) string {
+	if symbol1 < symbol0 {
+		symbol0, symbol1 = symbol1, symbol0
+	}
+	return symbol0 + "/" + symbol1
+}
+
+// NewCycleIndex precomputes the cycles over the tokens of tokenList and the pools of snapshot, and evaluates them all.
+// Each cycle appears once per direction and is anchored on a Hold token (see anchorCycle); cycles without one are skipped.
+func NewCycleIndex(tokenList *models.TokenList, snapshot *models.PoolSnapshot) *CycleIndex {
+	ci := &CycleIndex{
+		byPool: make(map[string][]*Cycle),
+		byPair: make(map[string][]string),
+	}
+	for _, pool := range snapshot.ListPools() {
+		key := pairKey(pool.Token0.Symbol, pool.Token1.Symbol)
+		ci.byPair[key] = append(ci.byPair[key], pool.Key())
+	}
+
+	tokenSymbols := tokenList.GetTokenSymbolList()
+	sort.Strings(tokenSymbols)
+	for a, tokenA := range tokenSymbols {
+		for _, tokenB := range tokenSymbols[a+1:] {
+			for _, tokenC := range tokenSymbols[a+1:] {
+				if tokenC == tokenB {
+					continue
+				}
+				hops := [3]string{pairKey(tokenA, tokenB), pairKey(tokenB, tokenC), pairKey(tokenC, tokenA)}
+				if len(ci.byPair[hops[0]]) == 0 || len(ci.byPair[hops[1]]) == 0 || len(ci.byPair[hops[2]]) == 0 {
+					continue
+				}
+
+				tokens, ok := anchorCycle(tokenList, [3]string{tokenA, tokenB, tokenC})
+				if !ok {
+					continue
+				}
+				cycle := &Cycle{Tokens: tokens, index: -1}
+				ci.cycles = append(ci.cycles, cycle)
+				for _, hop := range hops {
+					for _, poolKey := range ci.byPair[hop] {
+						ci.byPool[poolKey] = append(ci.byPool[poolKey], cycle)
+					}
+				}
+				ci.evaluate(snapshot, cycle)
+			}
+		}
+	}
+	log.Printf("Indexed %v cycles over %v pools", len(ci.cycles), len(snapshot.ListPools()))
+	return ci
+}
+
+// anchorCycle rotates a cycle so that it starts (and ends) in a Hold token.
+// Returns false if the cycle holds no Hold token, as its exposure could not be closed in a safe token.
+func anchorCycle(tokenList *models.TokenList, tokens [3]string) ([3]string, bool) {
+	for k := 0; k < 3; k++ {
+		token, err := tokenList.GetTokenBySymbol(tokens[k])
+		if err != nil || !token.Hold {
+			continue
+		}
+		return [3]string{tokens[k], tokens[(k+1)%3], tokens[(k+2)%3]}, true
+	}
+	return tokens, false
+}
+
+// Len returns the number of indexed cycles.
+func (ci *CycleIndex) Len() int {
+	return len(ci.cycles)
+}
+
+// Update re-evaluates the cycles touching any of the given pools and returns them.
+func (ci *CycleIndex) Update(snapshot *models.PoolSnapshot, poolKeys []string) []*Cycle {
+	seen := make(map[*Cycle]bool)
+	var affected []*Cycle
+	for _, poolKey := range poolKeys {
+		for _, cycle := range ci.byPool[poolKey] {
+			if seen[cycle] {
+				continue
+			}
+			seen[cycle] = true
+			ci.evaluate(snapshot, cycle)
+			affected = append(affected, cycle)
+		}
+	}
+	return affected
+}
+
+// Top returns up to n cycles with the highest multipliers, best first.
+func (ci *CycleIndex) Top(n int) []*Cycle {
+	ranked := make([]*Cycle, len(ci.ranking))
+	copy(ranked, ci.ranking)
+	sort.Slice(ranked, func(a, b int) bool {
+		return ranked[a].Multiplier.Cmp(ranked[b].Multiplier) > 0
+	})
+	if n < len(ranked) {
+		ranked = ranked[:n]
+	}
+	return ranked
+}
+
+// Best returns the cycle with the highest multiplier, or nil if no cycle is priced.
+func (ci *CycleIndex) Best() *Cycle {
+	if len(ci.ranking) == 0 {
+		return nil
+	}
+	return ci.ranking[0]
+}
+
+// evaluate picks the best pool of every hop and updates the cycle's rank
+func (ci *CycleIndex) evaluate(snapshot *models.PoolSnapshot, cycle *Cycle) {
+	multiplier := big.NewFloat(1)
+	for hop := 0; hop < 3; hop++ {
+		from, to := cycle.Tokens[hop], cycle.Tokens[(hop+1)%3]
+
+		var bestPool *models.Pool
+		var bestRate *big.Float
+		for _, poolKey := range ci.byPair[pairKey(from, to)] {
+			pool, err := snapshot.GetPoolByKey(poolKey)
+			if err != nil {
+				continue
+			}
+			rate, err := pool.RateFromToken(from)
+			if err != nil || rate == nil {
+				continue
+			}
+			if bestRate == nil || rate.Cmp(bestRate) > 0 {
+				bestPool, bestRate = pool, rate
+			}
+		}
+		if bestPool == nil {
+			multiplier = nil
+			break
+		}
+		cycle.Pools[hop] = bestPool
+		multiplier.Mul(multiplier, bestRate)
+	}
+	cycle.Multiplier = multiplier
+
+	switch {
+	case multiplier == nil && cycle.index >= 0:
+		heap.Remove(&ci.ranking, cycle.index)
+	case multiplier != nil && cycle.index >= 0:
+		heap.Fix(&ci.ranking, cycle.index)
+	case multiplier != nil:
+		heap.Push(&ci.ranking, cycle)
+	}
+}
+
+// cycleHeap is a max-heap of cycles by multiplier (container/heap)
+type cycleHeap []*Cycle
+
+func (h cycleHeap) Len() int           { return len(h) }
+func (h cycleHeap) Less(a, b int) bool { return h[a].Multiplier.Cmp(h[b].Multiplier) > 0 }
+func (h cycleHeap) Swap(a, b int) {
+	h[a], h[b] = h[b], h[a]
+	h[a].index = a
+	h[b].index = b
+}
+
+func (h *cycleHeap) Push(x any) {
+	cycle := x.(*Cycle)
+	cycle.index = len(*h)
+	*h = append(*h, cycle)
+}
+
+func (h *cycleHeap) Pop() any {
+	old := *h
+	cycle := old[len(old)-1]
+	cycle.index = -1
+	*h = old[:len(old)-1]
+	return cycle
+}
+
+// IncrementalArbitrageStrategy re-evaluates only the cycles touching the updated pools and reports (and returns) those exceeding minimumMultiplier.
+func IncrementalArbitrageStrategy(cycleIndex *CycleIndex, tokenList *models.TokenList, snapshot *models.PoolSnapshot, updatedPoolKeys []string, minimumMultiplier *big.Float) []models.OpportunityEvent {
+	var opportunities []models.OpportunityEvent
+	affected := cycleIndex.Update(snapshot, updatedPoolKeys)
+	for _, cycle := range affected {
+		if cycle.Multiplier != nil && cycle.Multiplier.Cmp(minimumMultiplier) > 0 {
+			opportunities = append(opportunities, reportOpportunity(tokenList, snapshot, cycle.Tokens, cycle.Pools, cycle.Multiplier))
+		}
+	}
+	if best := cycleIndex.Best(); best != nil {
+		log.Printf("Re-evaluated %v/%v cycles, best: %v -> %v -> %v (%v)", len(affected), cycleIndex.Len(), best.Tokens[0], best.Tokens[1], best.Tokens[2], best.Multiplier)
+	}
+	return opportunities
+}
@@ -0,0 +1,136 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"198/erc20"
+	"198/models"
+)
+
+// InventoryPolicy holds the target balance of every Hold token.
+// Cycles always return to the Hold token they started from, so drift only comes from profits, losses and manual transfers.
+type InventoryPolicy struct {
+	Targets   map[string]*big.Float // Hold token symbol -> target balance in whole tokens
+	Tolerance float64               // Relative drift from the target tolerated before rebalancing (0.1 = 10%)
+}
+
+// RebalanceTrade is a proposed swap moving surplus of one Hold token into a Hold token below its target.
+type RebalanceTrade struct {
+	From        string
+	To          string
+	AmountIn    *models.Amount
+	ExpectedOut *models.Amount // At the pool's current rate
+	Pool        *models.Pool
+}
+
+// FetchHoldBalances reads the wallet's balance of every Hold token.
+func FetchHoldBalances(ethClient *ethclient.Client, tokenList *models.TokenList, wallet common.Address) (map[string]*models.Amount, error) {
+	balances := make(map[string]*models.Amount)
+	for _, token := range tokenList.ListTokens() {
+		if !token.Hold {
+			continue
+		}
+		tokenContract, err := erc20.NewErc20(common.HexToAddress(token.Address), ethClient)
+		if err != nil {
+			return nil, err
+		}
+		balance, err := tokenContract.BalanceOf(&bind.CallOpts{Context: context.Background()}, wallet)
+		if err != nil {
+			return nil, err
+		}
+		balances[token.Symbol] = models.NewAmount(token, balance)
+	}
+	return balances, nil
+}
+
+// Rebalance proposes trades from the Hold tokens above target + tolerance to those below target - tolerance.
+// The largest surplus is paired with the largest deficit first (in whole tokens, Hold tokens being stablecoins);
+// each trade goes through the best direct pool of the snapshot and is sized exactly with the pool's quoter:
+// the input buying the whole deficit, or the whole surplus if that is not enough.
+func (p InventoryPolicy) Rebalance(balances map[string]*models.Amount, snapshot *models.PoolSnapshot) ([]RebalanceTrade, error) {
+	if len(p.Targets) == 0 {
+		return nil, errors.New("inventory policy has no targets")
+	}
+
+	type drift struct {
+		amount    *models.Amount // Above (surplus) or below (deficit) the target
+		tolerated *models.Amount // Drift tolerated around the target
+	}
+	var surpluses, deficits []drift
+	for symbol, target := range p.Targets {
+		balance, ok := balances[symbol]
+		if !ok {
+			log.Printf("WARNING: no balance of %v to compare with its target", symbol)
+			continue
+		}
+		targetAmount := models.AmountFromFloat(balance.Token, target)
+		tolerated := models.AmountFromFloat(balance.Token, new(big.Float).Mul(target, big.NewFloat(p.Tolerance)))
+		difference, err := balance.Sub(targetAmount)
+		if err != nil {
+			return nil, err
+		}
+		if new(big.Int).Abs(difference.Raw).Cmp(tolerated.Raw) <= 0 {
+			continue
+		}
+		if difference.Sign() > 0 {
+			surpluses = append(surpluses, drift{difference, tolerated})
+		} else {
+			deficits = append(deficits, drift{models.NewAmount(difference.Token, new(big.Int).Neg(difference.Raw)), tolerated})
+		}
+	}
+	sort.Slice(surpluses, func(a, b int) bool { return surpluses[a].amount.Float().Cmp(surpluses[b].amount.Float()) > 0 })
+	sort.Slice(deficits, func(a, b int) bool { return deficits[a].amount.Float().Cmp(deficits[b].amount.Float()) > 0 })
+
+	var trades []RebalanceTrade
+	for _, deficit := range deficits {
+		for k := range surpluses {
+			surplus := &surpluses[k]
+			if surplus.amount.Sign() <= 0 || deficit.amount.Sign() <= 0 {
+				continue
+			}
+			pool, err := snapshot.GetBestPoolForTokens(surplus.amount.Token.Symbol, deficit.amount.Token.Symbol)
+			if err != nil || pool.Quoter == nil {
+				continue
+			}
+
+			// Sell only what covers the deficit, up to the whole surplus
+			amountIn, err := pool.Quoter.AmountIn(deficit.amount, surplus.amount.Token)
+			switch {
+			case errors.Is(err, models.ErrInsufficientLiquidity):
+				amountIn = surplus.amount
+			case err != nil:
+				continue
+			default:
+				if cmp, _ := amountIn.Cmp(surplus.amount); cmp > 0 {
+					amountIn = surplus.amount
+				}
+			}
+			amountOut, err := pool.Quoter.AmountOut(amountIn, deficit.amount.Token)
+			if err != nil || amountOut.Sign() <= 0 {
+				continue
+			}
+			surplus.amount, _ = surplus.amount.Sub(amountIn)
+			deficit.amount, _ = deficit.amount.Sub(amountOut)
+
+			trades = append(trades, RebalanceTrade{
+				From:        amountIn.Token.Symbol,
+				To:          amountOut.Token.Symbol,
+				AmountIn:    amountIn,
+				ExpectedOut: amountOut,
+				Pool:        pool,
+			})
+		}
+		if cmp, _ := deficit.amount.Cmp(deficit.tolerated); cmp > 0 {
+			log.Printf("WARNING: no surplus left to cover %v below target", deficit.amount)
+		}
+	}
+	return trades, nil
+}
@@ -0,0 +1,66 @@
+package strategy
+
+import (
+	"math/big"
+	"testing"
+
+	"198/models"
+	"198/utils"
+)
+
+func TestRebalance(t *testing.T) {
+	usdc := &models.Token{Symbol: "USDC", Address: "0x00000000000000000000000000000000000000c1", Decimals: 6, Hold: true}
+	usdt := &models.Token{Symbol: "USDT", Address: "0x00000000000000000000000000000000000000c2", Decimals: 6, Hold: true}
+
+	// 1M/1M pool at 0.05%
+	reserve := big.NewInt(1e12)
+	fee := big.NewInt(500)
+	poolList, err := models.NewPoolListFromSlice([]*models.Pool{{Address: "0x01", DEX: "UniswapV2", Fee: fee, Token0: usdc, Token1: usdt}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	quoter := utils.ConstantProductQuoter{Token0: usdc, Token1: usdt, Reserve0: reserve, Reserve1: reserve, Fee: fee}
+	if err := poolList.UpdatePoolAmountOutsByKey("0x01", 1, nil, models.NewAmount(usdt, big.NewInt(999500)), models.NewAmount(usdc, big.NewInt(999500)), reserve, reserve, quoter); err != nil {
+		t.Fatal(err)
+	}
+	policy := InventoryPolicy{
+		Targets:   map[string]*big.Float{"USDC": big.NewFloat(1000), "USDT": big.NewFloat(1000)},
+		Tolerance: 0.1,
+	}
+
+	// 600 USDC over and 500 USDT under the targets: buy exactly 500 USDT (getAmountIn rounds up)
+	trades, err := policy.Rebalance(map[string]*models.Amount{
+		"USDC": models.NewAmount(usdc, big.NewInt(1600e6)),
+		"USDT": models.NewAmount(usdt, big.NewInt(500e6)),
+	}, poolList.Snapshot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 1 {
+		t.Fatalf("%d trades, want 1", len(trades))
+	}
+	if trade := trades[0]; trade.From != "USDC" || trade.To != "USDT" || trade.AmountIn.Raw.Int64() != 500500376 || trade.ExpectedOut.Raw.Int64() != 500e6 {
+		t.Errorf("trade %v -> %v, want 500.500376 USDC -> 500 USDT", trade.AmountIn, trade.ExpectedOut)
+	}
+
+	// A 150 USDC surplus cannot cover the deficit: all of it is sold
+	trades, err = policy.Rebalance(map[string]*models.Amount{
+		"USDC": models.NewAmount(usdc, big.NewInt(1150e6)),
+		"USDT": models.NewAmount(usdt, big.NewInt(500e6)),
+	}, poolList.Snapshot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 1 || trades[0].AmountIn.Raw.Int64() != 150e6 || trades[0].ExpectedOut.Raw.Int64() != 149902525 {
+		t.Errorf("trades %+v, want 150 USDC -> 149.902525 USDT", trades)
+	}
+
+	// Within the tolerance nothing is traded
+	trades, err = policy.Rebalance(map[string]*models.Amount{
+		"USDC": models.NewAmount(usdc, big.NewInt(1050e6)),
+		"USDT": models.NewAmount(usdt, big.NewInt(950e6)),
+	}, poolList.Snapshot())
+	if err != nil || len(trades) != 0 {
+		t.Errorf("trades %+v (%v) within the tolerance", trades, err)
+	}
+}
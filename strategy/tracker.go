@@ -0,0 +1,201 @@
+package strategy
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"198/models"
+)
+
+// TrackedOpportunity is the lifecycle of one opportunity, identified by its ordered pool path.
+type TrackedOpportunity struct {
+	ID                string     `json:"id"` // Pool keys along the path, joined by ">"
+	Tokens            []string   `json:"tokens"`
+	PoolKeys          []string   `json:"poolKeys"`
+	OpenedBlock       uint64     `json:"openedBlock"`
+	OpenedAt          time.Time  `json:"openedAt"`
+	OpenedBy          string     `json:"openedBy,omitempty"` // Log id of the swap that made the path profitable
+	PeakMultiplier    float64    `json:"peakMultiplier"`
+	CurrentMultiplier float64    `json:"currentMultiplier"`
+	PeakProfit        *float64   `json:"peakProfit,omitempty"` // Whole Tokens[0], only for sized opportunities
+	CurrentProfit     *float64   `json:"currentProfit,omitempty"`
+	LastBlock         uint64     `json:"lastBlock"`
+	Updates           int        `json:"updates"`
+	ClosedBlock       uint64     `json:"closedBlock,omitempty"`
+	ClosedAt          *time.Time `json:"closedAt,omitempty"`
+	ClosedBy          string     `json:"closedBy,omitempty"`   // Log id of the swap after which the path was no longer profitable, or ClosedByPoolRemoved
+	Suspicious        []string   `json:"suspicious,omitempty"` // Pools found off their TWAP in any round (see ScreenOpportunities)
+}
+
+// ClosedByPoolRemoved is the closing reason of opportunities whose pools were removed from the configuration
+const ClosedByPoolRemoved = "pool removed"
+
+// OpportunityTracker follows opportunities from the round they become profitable until the round they are not.
+// Closed opportunities are appended to a JSON lines file.
+type OpportunityTracker struct {
+	open    map[string]*TrackedOpportunity
+	file    *os.File
+	encoder *json.Encoder
+}
+
+// opportunityID is the stable identity of an opportunity: its ordered pool path
+func opportunityID(poolKeys []string) string {
+	return strings.Join(poolKeys, ">")
+}
+
+// NewOpportunityTracker opens (or appends to) the JSON lines file at path.
+func NewOpportunityTracker(path string) (*OpportunityTracker, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		return nil, err
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetEscapeHTML(false) // keep the ">" of ids readable
+	return &OpportunityTracker{
+		open:    make(map[string]*TrackedOpportunity),
+		file:    file,
+		encoder: encoder,
+	}, nil
+}
+
+// Round records the opportunities found in snapshot after the given pools were updated.
+// Opportunities already open are updated; open ones with a hop on the pair of an updated pool that were not found again are closed
+// (another pool of the pair may now be the better route).
+// triggers maps each updated pool key to the log id of its swap. The opportunities closed in this round are returned.
+func (t *OpportunityTracker) Round(snapshot *models.PoolSnapshot, triggers map[string]string, opportunities []models.OpportunityEvent) []*TrackedOpportunity {
+	now := time.Now()
+	blockNumber := snapshot.BlockNumber
+
+	// Log id of the swap that re-evaluated each token pair
+	pairTriggers := make(map[string]string)
+	for poolKey, trigger := range triggers {
+		if pool, err := snapshot.GetPoolByKey(poolKey); err == nil {
+			pairTriggers[pairKey(pool.Token0.Symbol, pool.Token1.Symbol)] = trigger
+		}
+	}
+	seen := make(map[string]bool)
+	var closed []*TrackedOpportunity
+
+	for _, opportunity := range opportunities {
+		id := opportunityID(opportunity.PoolKeys)
+		seen[id] = true
+
+		multiplier, _ := opportunity.Multiplier.Float64()
+		var profit *float64
+		if opportunity.Profit != nil {
+			value := opportunity.Profit.Float64()
+			profit = &value
+		}
+
+		tracked, exists := t.open[id]
+		if !exists {
+			tracked = &TrackedOpportunity{
+				ID:             id,
+				Tokens:         opportunity.Tokens,
+				PoolKeys:       opportunity.PoolKeys,
+				OpenedBlock:    blockNumber,
+				OpenedAt:       now,
+				OpenedBy:       triggerOf(opportunity.PoolKeys, triggers),
+				PeakMultiplier: multiplier,
+				PeakProfit:     profit,
+			}
+			t.open[id] = tracked
+			log.Printf("Opened opportunity %v (%v) at block %v", id, multiplier, blockNumber)
+		}
+		tracked.CurrentMultiplier = multiplier
+		tracked.CurrentProfit = profit
+		tracked.LastBlock = blockNumber
+		tracked.Updates++
+		if multiplier > tracked.PeakMultiplier {
+			tracked.PeakMultiplier = multiplier
+		}
+		if profit != nil && (tracked.PeakProfit == nil || *profit > *tracked.PeakProfit) {
+			tracked.PeakProfit = profit
+		}
+		for _, poolKey := range opportunity.Suspicious {
+			if !slices.Contains(tracked.Suspicious, poolKey) {
+				tracked.Suspicious = append(tracked.Suspicious, poolKey)
+			}
+		}
+	}
+
+	// Only paths through an updated pool were re-evaluated, the others keep their state
+	for id, tracked := range t.open {
+		if seen[id] {
+			continue
+		}
+		trigger := triggerOf(tracked.PoolKeys, triggers)
+		for k := 0; trigger == "" && k+1 < len(tracked.Tokens); k++ {
+			trigger = pairTriggers[pairKey(tracked.Tokens[k], tracked.Tokens[k+1])]
+		}
+		if trigger == "" {
+			continue
+		}
+		tracked.ClosedBlock = blockNumber
+		tracked.ClosedAt = &now
+		tracked.ClosedBy = trigger
+		t.persist(tracked)
+		delete(t.open, id)
+		closed = append(closed, tracked)
+		log.Printf("Closed opportunity %v after %v blocks (peak: %v)", id, blockNumber-tracked.OpenedBlock, tracked.PeakMultiplier)
+	}
+	return closed
+}
+
+// PoolsRemoved closes the open opportunities through any of the removed pools, which can no longer be re-evaluated.
+// They are persisted with "pool removed" as their closing reason and returned.
+func (t *OpportunityTracker) PoolsRemoved(poolKeys []string, blockNumber uint64) []*TrackedOpportunity {
+	now := time.Now()
+	var closed []*TrackedOpportunity
+	for id, tracked := range t.open {
+		if !slices.ContainsFunc(tracked.PoolKeys, func(poolKey string) bool { return slices.Contains(poolKeys, poolKey) }) {
+			continue
+		}
+		tracked.ClosedBlock = blockNumber
+		tracked.ClosedAt = &now
+		tracked.ClosedBy = ClosedByPoolRemoved
+		t.persist(tracked)
+		delete(t.open, id)
+		closed = append(closed, tracked)
+		log.Printf("Closed opportunity %v after %v blocks (peak: %v): pool removed", id, blockNumber-tracked.OpenedBlock, tracked.PeakMultiplier)
+	}
+	return closed
+}
+
+// Open returns the opportunities currently open.
+func (t *OpportunityTracker) Open() []*TrackedOpportunity {
+	open := make([]*TrackedOpportunity, 0, len(t.open))
+	for _, tracked := range t.open {
+		open = append(open, tracked)
+	}
+	return open
+}
+
+// Close persists the opportunities still open (with no closing block) and closes the file.
+func (t *OpportunityTracker) Close() error {
+	for _, tracked := range t.open {
+		t.persist(tracked)
+	}
+	t.open = make(map[string]*TrackedOpportunity)
+	return t.file.Close()
+}
+
+func (t *OpportunityTracker) persist(tracked *TrackedOpportunity) {
+	if err := t.encoder.Encode(tracked); err != nil {
+		log.Printf("Failed to persist opportunity %v: %v", tracked.ID, err)
+	}
+}
+
+// triggerOf returns the log id of the first updated pool along the path, or "" if none was updated
+func triggerOf(poolKeys []string, triggers map[string]string) string {
+	for _, poolKey := range poolKeys {
+		if trigger, ok := triggers[poolKey]; ok {
+			return trigger
+		}
+	}
+	return ""
+}
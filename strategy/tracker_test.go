@@ -0,0 +1,60 @@
+package strategy
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPoolsRemovedClosesTheirOpportunities(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "opportunities.jsonl")
+	tracker, err := NewOpportunityTracker(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	through := func(poolKeys ...string) *TrackedOpportunity {
+		tracked := &TrackedOpportunity{ID: opportunityID(poolKeys), PoolKeys: poolKeys, OpenedBlock: 10}
+		tracker.open[tracked.ID] = tracked
+		return tracked
+	}
+	removed := through("a", "b")
+	kept := through("c", "d")
+
+	closed := tracker.PoolsRemoved([]string{"b", "x"}, 15)
+	if len(closed) != 1 || closed[0] != removed {
+		t.Fatalf("closed %v, want only %v", closed, removed.ID)
+	}
+	if removed.ClosedBlock != 15 || removed.ClosedAt == nil || removed.ClosedBy != ClosedByPoolRemoved {
+		t.Errorf("closed as (%v, %v, %q)", removed.ClosedBlock, removed.ClosedAt, removed.ClosedBy)
+	}
+	if open := tracker.Open(); len(open) != 1 || open[0] != kept {
+		t.Errorf("open %v, want only %v", open, kept.ID)
+	}
+	if closed := tracker.PoolsRemoved([]string{"x"}, 16); len(closed) != 0 {
+		t.Errorf("closed %v through no removed pool", closed)
+	}
+
+	// The close record is on file before the tracker itself is closed
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	var records []TrackedOpportunity
+	for scanner.Scan() {
+		var record TrackedOpportunity
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 1 || records[0].ID != removed.ID || records[0].ClosedBy != ClosedByPoolRemoved {
+		t.Errorf("persisted %+v", records)
+	}
+	if err := tracker.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,174 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/big"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+
+	"198/dex/quickswapv3"
+	"198/dex/uniswapv3"
+	"198/models"
+	"198/utils"
+)
+
+// TWAP is the time-weighted average tick of a pool over each window, as read at BlockNumber.
+type TWAP struct {
+	Windows     []uint32 // Seconds
+	Ticks       []int    // Average tick (token1 per token0) over the matching window
+	BlockNumber uint64
+}
+
+// TWAPOracle reads the average ticks of concentrated-liquidity pools from their own oracles
+// (observe on Uniswap V3 forks, getTimepoints on Algebra) and keeps the latest read of each pool.
+// Reads happen off the strategy loop (Refresh); screening only uses the cached values.
+type TWAPOracle struct {
+	caller     bind.ContractCaller
+	twaps      map[string]TWAP // Pool key -> latest read
+	mutex      sync.RWMutex
+	refreshing atomic.Bool // Set while a Refresh runs
+}
+
+// NewTWAPOracle creates an oracle reader with an empty cache.
+func NewTWAPOracle(caller bind.ContractCaller) *TWAPOracle {
+	return &TWAPOracle{caller: caller, twaps: make(map[string]TWAP)}
+}
+
+// Refresh reads the average ticks over windows of every oracle-capable pool of snapshot at blockNumber.
+// Pools whose oracle does not reach back far enough keep no entry.
+// A call made while the previous refresh still runs is skipped, so slow RPCs cannot pile up reads.
+func (o *TWAPOracle) Refresh(ctx context.Context, snapshot *models.PoolSnapshot, windows []uint32, blockNumber uint64) {
+	if !o.refreshing.CompareAndSwap(false, true) {
+		log.Printf("Skipping the TWAP refresh at block %v, the previous one is still running", blockNumber)
+		return
+	}
+	defer o.refreshing.Store(false)
+
+	for _, pool := range snapshot.ListPools() {
+		if !hasOracle(pool.DEX) {
+			continue
+		}
+		ticks, err := o.AverageTicks(ctx, pool, windows, blockNumber)
+		if err != nil {
+			log.Printf("Failed to read the TWAP of %v (%v): %v", pool.Key(), pool.DEX, err)
+			o.mutex.Lock()
+			delete(o.twaps, pool.Key())
+			o.mutex.Unlock()
+			continue
+		}
+		o.mutex.Lock()
+		o.twaps[pool.Key()] = TWAP{Windows: windows, Ticks: ticks, BlockNumber: blockNumber}
+		o.mutex.Unlock()
+	}
+}
+
+// AverageTicks reads the pool's tick cumulatives for every window in one call and averages them (OracleLibrary.consult).
+func (o *TWAPOracle) AverageTicks(ctx context.Context, pool *models.Pool, windows []uint32, blockNumber uint64) ([]int, error) {
+	secondsAgos := append(append([]uint32(nil), windows...), 0)
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(blockNumber)}
+	address := common.HexToAddress(pool.Address)
+
+	var tickCumulatives []*big.Int
+	if pool.DEX == "QuickswapV3" {
+		caller, err := quickswapv3.NewQuickswapv3Caller(address, o.caller)
+		if err != nil {
+			return nil, err
+		}
+		timepoints, err := caller.GetTimepoints(opts, secondsAgos)
+		if err != nil {
+			return nil, err
+		}
+		tickCumulatives = timepoints.TickCumulatives
+	} else {
+		caller, err := uniswapv3.NewUniswapv3Caller(address, o.caller)
+		if err != nil {
+			return nil, err
+		}
+		observations, err := caller.Observe(opts, secondsAgos)
+		if err != nil {
+			return nil, err
+		}
+		tickCumulatives = observations.TickCumulatives
+	}
+	if len(tickCumulatives) != len(secondsAgos) {
+		return nil, errors.New("unexpected oracle response length")
+	}
+	return averageTicks(tickCumulatives, windows), nil
+}
+
+// Get returns the latest read of a pool's TWAP.
+func (o *TWAPOracle) Get(poolKey string) (TWAP, bool) {
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+	twap, ok := o.twaps[poolKey]
+	return twap, ok
+}
+
+// Deviation is the largest distance in ticks between the pool's spot tick and its average over any window.
+// ok is false for pools without a cached TWAP or a concentrated-liquidity quoter.
+func (o *TWAPOracle) Deviation(pool *models.Pool) (deviation int, ok bool) {
+	twap, found := o.Get(pool.Key())
+	quoter, isConcentrated := pool.Quoter.(utils.ConcentratedLiquidityQuoter)
+	if !found || !isConcentrated || quoter.SqrtPriceX96 == nil {
+		return 0, false
+	}
+	sqrtPrice, overflow := uint256.FromBig(quoter.SqrtPriceX96)
+	if overflow {
+		return 0, false
+	}
+	spot, err := utils.GetTickAtSqrtRatio(sqrtPrice)
+	if err != nil {
+		return 0, false
+	}
+	for _, tick := range twap.Ticks {
+		distance := spot - tick
+		if distance < 0 {
+			distance = -distance
+		}
+		if distance > deviation {
+			deviation = distance
+		}
+	}
+	return deviation, true
+}
+
+// ScreenOpportunities marks the pools of each opportunity whose spot price strays more than maxDeviation ticks from their TWAP.
+// Such a price was likely moved within the block (e.g. a sandwich or an oracle manipulation) and may not be there to trade against.
+func ScreenOpportunities(oracle *TWAPOracle, snapshot *models.PoolSnapshot, opportunities []models.OpportunityEvent, maxDeviation int) {
+	for k := range opportunities {
+		opportunity := &opportunities[k]
+		for _, poolKey := range opportunity.PoolKeys {
+			pool, err := snapshot.GetPoolByKey(poolKey)
+			if err != nil {
+				continue
+			}
+			if deviation, ok := oracle.Deviation(pool); ok && deviation > maxDeviation {
+				opportunity.Suspicious = append(opportunity.Suspicious, poolKey)
+				log.Printf("Suspicious opportunity %v: %v spot price is %v ticks from its TWAP", opportunity.Tokens, poolKey, deviation)
+			}
+		}
+	}
+}
+
+// hasOracle reports whether the DEX's pools expose a tick oracle
+func hasOracle(dex string) bool {
+	return dex == "UniswapV3" || dex == "SushiswapV3" || dex == "QuickswapV3"
+}
+
+// averageTicks averages the cumulatives of secondsAgos (windows..., 0), rounding towards negative infinity
+func averageTicks(tickCumulatives []*big.Int, windows []uint32) []int {
+	latest := tickCumulatives[len(windows)]
+	ticks := make([]int, len(windows))
+	for k, window := range windows {
+		delta := new(big.Int).Sub(latest, tickCumulatives[k])
+		// Floor division, as Go's Div is Euclidean for a positive divisor
+		ticks[k] = int(new(big.Int).Div(delta, big.NewInt(int64(window))).Int64())
+	}
+	return ticks
+}
@@ -0,0 +1,160 @@
+package strategy
+
+import (
+	"log"
+	"math/big"
+
+	"198/models"
+	"198/utils"
+)
+
+// TwoPoolStrategy compares every updated pool with the other pools trading the same two tokens (other DEXes or fee tiers).
+// Buying on one pool and selling on the other is reported when the product of both fee-inclusive rates exceeds minimumMultiplier.
+// When both pools expose reserves, the input is sized where the marginal prices of the two legs meet.
+// Legs start and end in the pair's Hold token; pairs without one are skipped. The reported opportunities are returned.
+func TwoPoolStrategy(tokenList *models.TokenList, snapshot *models.PoolSnapshot, updatedPoolKeys []string, minimumMultiplier *big.Float) []models.OpportunityEvent {
+	var opportunities []models.OpportunityEvent
+	pools := snapshot.ListPools()
+	seen := make(map[[2]string]bool)
+
+	for _, key := range updatedPoolKeys {
+		pool, err := snapshot.GetPoolByKey(key)
+		if err != nil {
+			continue
+		}
+		anchor := ""
+		for _, token := range []*models.Token{pool.Token0, pool.Token1} {
+			if holdToken, err := tokenList.GetTokenBySymbol(token.Symbol); err == nil && holdToken.Hold {
+				anchor = token.Symbol
+				break
+			}
+		}
+		if anchor == "" {
+			continue
+		}
+		for _, other := range pools {
+			if other.Key() == pool.Key() || pairKey(other.Token0.Symbol, other.Token1.Symbol) != pairKey(pool.Token0.Symbol, pool.Token1.Symbol) {
+				continue
+			}
+			if seen[[2]string{pool.Key(), other.Key()}] {
+				continue
+			}
+			seen[[2]string{pool.Key(), other.Key()}] = true
+			seen[[2]string{other.Key(), pool.Key()}] = true
+
+			// Both directions: buy on one pool, sell on the other
+			for _, legs := range [][2]*models.Pool{{pool, other}, {other, pool}} {
+				if opportunity := evaluateTwoPool(snapshot, legs[0], legs[1], anchor, minimumMultiplier); opportunity != nil {
+					opportunities = append(opportunities, *opportunity)
+				}
+			}
+		}
+	}
+	return opportunities
+}
+
+// evaluateTwoPool checks tokenA -> tokenB on buyPool then tokenB -> tokenA on sellPool, returning the opportunity if profitable
+func evaluateTwoPool(snapshot *models.PoolSnapshot, buyPool, sellPool *models.Pool, tokenA string, minimumMultiplier *big.Float) *models.OpportunityEvent {
+	buyRate, err := buyPool.RateFromToken(tokenA)
+	if err != nil || buyRate == nil {
+		return nil
+	}
+	tokenB := buyPool.Token0.Symbol
+	if tokenB == tokenA {
+		tokenB = buyPool.Token1.Symbol
+	}
+	sellRate, err := sellPool.RateFromToken(tokenB)
+	if err != nil || sellRate == nil {
+		return nil
+	}
+
+	multiplier := new(big.Float).Mul(buyRate, sellRate)
+	if multiplier.Cmp(minimumMultiplier) <= 0 {
+		return nil
+	}
+
+	opportunity := models.OpportunityEvent{
+		Tokens:          []string{tokenA, tokenB, tokenA},
+		PoolKeys:        []string{buyPool.Key(), sellPool.Key()},
+		Multiplier:      multiplier,
+		SnapshotVersion: snapshot.Version,
+		BlockNumber:     snapshot.BlockNumber,
+	}
+	if amountIn, profit, ok := optimalTwoPoolSize(buyPool, sellPool, tokenA); ok {
+		opportunity.AmountIn, opportunity.Profit = amountIn, profit
+	}
+
+	log.Println("")
+	log.Printf("Two-pool Arbitrage opportunity: %v for %v -> %v -> %v (buy: %v %v) (sell: %v %v) (amount in: %v) (profit: %v) (snapshot: v%v @ block %v)", multiplier, tokenA, tokenB, tokenA, buyPool.DEX, buyPool.Fee, sellPool.DEX, sellPool.Fee, opportunity.AmountIn, opportunity.Profit, snapshot.Version, snapshot.BlockNumber)
+	return &opportunity
+}
+
+// rawReservesFrom returns the raw reserves of pool oriented for a swap from tokenSymbol, with the tokens in and out
+func rawReservesFrom(pool *models.Pool, tokenSymbol string) (*big.Int, *big.Int, *models.Token, *models.Token, bool) {
+	if pool.Reserve0 == nil || pool.Reserve1 == nil {
+		return nil, nil, nil, nil, false
+	}
+	if pool.Token0.Symbol == tokenSymbol {
+		return pool.Reserve0, pool.Reserve1, pool.Token0, pool.Token1, true
+	}
+	return pool.Reserve1, pool.Reserve0, pool.Token1, pool.Token0, true
+}
+
+// optimalTwoPoolSize returns the tokenA input maximizing tokenA -> tokenB on buyPool then back on sellPool, and the profit at that size.
+// Both legs are modelled as constant-product curves with their fee (virtual reserves for concentrated liquidity, valid within the current tick).
+// Chaining x1,y1 (fee g1) with y2,x2 (fee g2) gives out(dx) = N*dx / (D + M*dx), whose profit out(dx) - dx peaks at dx = (sqrt(N*D) - D) / M.
+// The size is solved in integers on the raw reserves, then both legs are requoted through the pools' quoters so the amounts are exact.
+func optimalTwoPoolSize(buyPool, sellPool *models.Pool, tokenA string) (*models.Amount, *models.Amount, bool) {
+	x1, y1, token, _, ok := rawReservesFrom(buyPool, tokenA)
+	if !ok {
+		return nil, nil, false
+	}
+	tokenB := buyPool.Token1.Symbol
+	if buyPool.Token1.Symbol == tokenA {
+		tokenB = buyPool.Token0.Symbol
+	}
+	y2, x2, middle, _, ok := rawReservesFrom(sellPool, tokenB)
+	if !ok || buyPool.Quoter == nil || sellPool.Quoter == nil {
+		return nil, nil, false
+	}
+
+	// Dynamic fees (e.g. the V4 flag) are not a fee fraction
+	denominator := big.NewInt(utils.FeeDenominator)
+	if buyPool.Fee == nil || sellPool.Fee == nil || buyPool.Fee.Cmp(denominator) >= 0 || sellPool.Fee.Cmp(denominator) >= 0 {
+		return nil, nil, false
+	}
+	g1 := new(big.Int).Sub(denominator, buyPool.Fee)
+	g2 := new(big.Int).Sub(denominator, sellPool.Fee)
+
+	// N, D and M scaled by FeeDenominator^2, which leaves dx unchanged
+	N := new(big.Int).Mul(g1, g2)
+	N.Mul(N, x2).Mul(N, y1)
+	D := new(big.Int).Mul(denominator, denominator)
+	D.Mul(D, x1).Mul(D, y2)
+	M := new(big.Int).Mul(g2, y1)
+	M.Add(M, new(big.Int).Mul(denominator, y2)).Mul(M, g1)
+	if N.Cmp(D) <= 0 || M.Sign() <= 0 {
+		return nil, nil, false
+	}
+
+	raw := new(big.Int).Mul(N, D)
+	raw.Sqrt(raw).Sub(raw, D).Quo(raw, M)
+	if raw.Sign() <= 0 {
+		return nil, nil, false
+	}
+
+	amountIn := models.NewAmount(token, raw)
+	amountB, err := buyPool.Quoter.AmountOut(amountIn, middle)
+	if err != nil {
+		return nil, nil, false
+	}
+	amountOut, err := sellPool.Quoter.AmountOut(amountB, token)
+	if err != nil {
+		return nil, nil, false
+	}
+	profit, err := amountOut.Sub(amountIn)
+	if err != nil || profit.Sign() <= 0 {
+		return nil, nil, false
+	}
+	return amountIn, profit, true
+}
@@ -0,0 +1,112 @@
+package strategy
+
+import (
+	"math/big"
+	"testing"
+
+	"198/models"
+	"198/utils"
+)
+
+var (
+	testTokenA = &models.Token{Symbol: "A", Address: "0x00000000000000000000000000000000000000aa", Decimals: 18}
+	testTokenB = &models.Token{Symbol: "B", Address: "0x00000000000000000000000000000000000000bb", Decimals: 18}
+)
+
+// constantProductPool is an A/B pool with its reserves and quoter set, as after a swap event
+func constantProductPool(address string, reserveA, reserveB, fee int64) *models.Pool {
+	reserve0, reserve1, feeAmount := big.NewInt(reserveA), big.NewInt(reserveB), big.NewInt(fee)
+	return &models.Pool{
+		Address:  address,
+		DEX:      "UniswapV2",
+		Fee:      feeAmount,
+		Token0:   testTokenA,
+		Token1:   testTokenB,
+		Reserve0: reserve0,
+		Reserve1: reserve1,
+		Quoter:   utils.ConstantProductQuoter{Token0: testTokenA, Token1: testTokenB, Reserve0: reserve0, Reserve1: reserve1, Fee: feeAmount},
+	}
+}
+
+// roundTrip quotes amountIn of A through buyPool to B and back through sellPool
+func roundTrip(t *testing.T, buyPool, sellPool *models.Pool, amountIn *big.Int) *big.Int {
+	t.Helper()
+	amountB, err := buyPool.Quoter.AmountOut(models.NewAmount(testTokenA, amountIn), testTokenB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	amountA, err := sellPool.Quoter.AmountOut(amountB, testTokenA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return amountA.Raw
+}
+
+func TestOptimalTwoPoolSize(t *testing.T) {
+	// Without fees, buying B at 2 per A (1000/2000) and selling it at 2 A per B (2000/1000) chains to
+	// out(dx) = 4e6*dx / (1e6 + 3000*dx), whose profit peaks at dx = (sqrt(4e6*1e6) - 1e6) / 3000 = 333.3.
+	// 333 A buys 2000*333/1333 = 499 B, which sell for 2000*499/1499 = 665 A.
+	buyPool := constantProductPool("0x01", 1000, 2000, 0)
+	sellPool := constantProductPool("0x02", 2000, 1000, 0)
+	amountIn, profit, ok := optimalTwoPoolSize(buyPool, sellPool, "A")
+	if !ok {
+		t.Fatal("no size found")
+	}
+	if amountIn.Raw.Int64() != 333 || profit.Raw.Int64() != 332 {
+		t.Errorf("size %v with profit %v, want 333 and 332", amountIn.Raw, profit.Raw)
+	}
+	if !models.SameToken(amountIn.Token, testTokenA) || !models.SameToken(profit.Token, testTokenA) {
+		t.Errorf("amounts in %v and %v, want A", amountIn.Token.Symbol, profit.Token.Symbol)
+	}
+
+	// With 0.3% on both legs (g = 0.997) the optimum moves to dx = (2g - 1) / (g * (2g + 1)) * 1e18 = 0.332996316...e18,
+	// and the quoted profit there is not beaten by a slightly smaller or larger input
+	buyPool = constantProductPool("0x01", 1e18, 2e18, 3000)
+	sellPool = constantProductPool("0x02", 2e18, 1e18, 3000)
+	amountIn, profit, ok = optimalTwoPoolSize(buyPool, sellPool, "A")
+	if !ok {
+		t.Fatal("no size found with fees")
+	}
+	if want := "332996316940132354"; amountIn.Raw.String() != want {
+		t.Errorf("size with fees %v, want %v", amountIn.Raw, want)
+	}
+	if got := new(big.Int).Sub(roundTrip(t, buyPool, sellPool, amountIn.Raw), amountIn.Raw); got.Cmp(profit.Raw) != 0 {
+		t.Errorf("profit %v, requoted %v", profit.Raw, got)
+	}
+	step := new(big.Int).Quo(amountIn.Raw, big.NewInt(100))
+	for _, other := range []*big.Int{new(big.Int).Sub(amountIn.Raw, step), new(big.Int).Add(amountIn.Raw, step)} {
+		if otherProfit := new(big.Int).Sub(roundTrip(t, buyPool, sellPool, other), other); otherProfit.Cmp(profit.Raw) > 0 {
+			t.Errorf("input %v earns %v, more than %v at the optimum", other, otherProfit, profit.Raw)
+		}
+	}
+}
+
+func TestOptimalTwoPoolSizeSkips(t *testing.T) {
+	skips := []struct {
+		name              string
+		buyPool, sellPool *models.Pool
+	}{
+		{"same price", constantProductPool("0x01", 1000, 2000, 0), constantProductPool("0x02", 1000, 2000, 0)},
+		{"fees eat the spread", constantProductPool("0x01", 1000, 2000, 3000), constantProductPool("0x02", 1000, 2004, 3000)},
+		{"buy fee at FeeDenominator", constantProductPool("0x01", 1000, 2000, utils.FeeDenominator), constantProductPool("0x02", 2000, 1000, 0)},
+		{"sell fee above FeeDenominator", constantProductPool("0x01", 1000, 2000, 0), constantProductPool("0x02", 2000, 1000, utils.FeeDenominator+1)},
+		{"dynamic fee flag", constantProductPool("0x01", 1000, 2000, 0), constantProductPool("0x02", 2000, 1000, 0x800000)},
+	}
+	for _, skip := range skips {
+		if amountIn, _, ok := optimalTwoPoolSize(skip.buyPool, skip.sellPool, "A"); ok {
+			t.Errorf("%s: sized %v", skip.name, amountIn.Raw)
+		}
+	}
+
+	// Pools without reserves or a quoter are not sized
+	noReserves := constantProductPool("0x01", 1000, 2000, 0)
+	noReserves.Reserve0 = nil
+	if _, _, ok := optimalTwoPoolSize(noReserves, constantProductPool("0x02", 2000, 1000, 0), "A"); ok {
+		t.Error("sized a pool without reserves")
+	}
+	noQuoter := constantProductPool("0x02", 2000, 1000, 0)
+	noQuoter.Quoter = nil
+	if _, _, ok := optimalTwoPoolSize(constantProductPool("0x01", 1000, 2000, 0), noQuoter, "A"); ok {
+		t.Error("sized a pool without a quoter")
+	}
+}
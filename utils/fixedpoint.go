@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/holiman/uint256"
+)
+
+// Exact integer math following the rounding rules of the pool contracts (FullMath, UnsafeMath, SwapMath).
+// Quotes are raw token amounts; bind them to their token with models.Amount for display.
+
+// Q96 is the 2^96 scale of sqrtPriceX96
+var Q96 = new(uint256.Int).Lsh(uint256.NewInt(1), 96)
+
+// FeeDenominator is the unit of pool fees, in hundredths of a bip (3000 = 0.3%)
+const FeeDenominator = 1_000_000
+
+var (
+	ErrDivisionByZero = errors.New("division by zero")
+	ErrOverflow       = errors.New("result overflows uint256")
+	ErrInvalidFee     = errors.New("fee must be below 1e6")
+)
+
+// MulDiv computes floor(a*b/denominator) with a 512-bit intermediate product (FullMath.mulDiv).
+func MulDiv(a, b, denominator *uint256.Int) (*uint256.Int, error) {
+	if denominator.IsZero() {
+		return nil, ErrDivisionByZero
+	}
+	result, overflow := new(uint256.Int).MulDivOverflow(a, b, denominator)
+	if overflow {
+		return nil, ErrOverflow
+	}
+	return result, nil
+}
+
+// MulDivRoundingUp computes ceil(a*b/denominator) (FullMath.mulDivRoundingUp).
+func MulDivRoundingUp(a, b, denominator *uint256.Int) (*uint256.Int, error) {
+	result, err := MulDiv(a, b, denominator)
+	if err != nil {
+		return nil, err
+	}
+	if !new(uint256.Int).MulMod(a, b, denominator).IsZero() {
+		if _, overflow := result.AddOverflow(result, uint256.NewInt(1)); overflow {
+			return nil, ErrOverflow
+		}
+	}
+	return result, nil
+}
+
+// DivRoundingUp computes ceil(x/y) (UnsafeMath.divRoundingUp).
+func DivRoundingUp(x, y *uint256.Int) (*uint256.Int, error) {
+	if y.IsZero() {
+		return nil, ErrDivisionByZero
+	}
+	quotient, remainder := new(uint256.Int), new(uint256.Int)
+	quotient.DivMod(x, y, remainder)
+	if !remainder.IsZero() {
+		quotient.AddUint64(quotient, 1)
+	}
+	return quotient, nil
+}
+
+// AmountLessFee is the part of an exact input left to trade after the pool fee, rounded down as in SwapMath.computeSwapStep.
+func AmountLessFee(amountIn *uint256.Int, fee uint64) (*uint256.Int, error) {
+	if fee >= FeeDenominator {
+		return nil, ErrInvalidFee
+	}
+	return MulDiv(amountIn, uint256.NewInt(FeeDenominator-fee), uint256.NewInt(FeeDenominator))
+}
+
+// SpotAmountOut is the output of amountIn at the constant price sqrtPriceX96, net of the fee and rounded down.
+// It ignores price impact: it is the exact marginal quote the pool would pay for an infinitesimally small trade, scaled to amountIn.
+func SpotAmountOut(sqrtPriceX96, amountIn *uint256.Int, fee uint64, zeroForOne bool) (*uint256.Int, error) {
+	if sqrtPriceX96.IsZero() {
+		return nil, ErrDivisionByZero
+	}
+	amountLessFee, err := AmountLessFee(amountIn, fee)
+	if err != nil {
+		return nil, err
+	}
+	// token1 = token0 * sqrtP^2 / 2^192, token0 = token1 * 2^192 / sqrtP^2, one factor at a time to stay within 512 bits
+	if zeroForOne {
+		partial, err := MulDiv(amountLessFee, sqrtPriceX96, Q96)
+		if err != nil {
+			return nil, err
+		}
+		return MulDiv(partial, sqrtPriceX96, Q96)
+	}
+	partial, err := MulDiv(amountLessFee, Q96, sqrtPriceX96)
+	if err != nil {
+		return nil, err
+	}
+	return MulDiv(partial, Q96, sqrtPriceX96)
+}
+
+// QuoteAtSqrtPrice is SpotAmountOut on big.Int values as decoded from events.
+func QuoteAtSqrtPrice(sqrtPriceX96, amountIn, fee *big.Int, zeroForOne bool) (*big.Int, error) {
+	sqrtPrice, amount, err := toUint256(sqrtPriceX96, amountIn)
+	if err != nil {
+		return nil, err
+	}
+	if fee == nil || fee.Sign() < 0 || !fee.IsUint64() {
+		return nil, ErrInvalidFee
+	}
+	amountOut, err := SpotAmountOut(sqrtPrice, amount, fee.Uint64(), zeroForOne)
+	if err != nil {
+		return nil, err
+	}
+	return amountOut.ToBig(), nil
+}
+
+// toUint256 converts non-negative big.Int values, failing on negatives and values above 2^256-1
+func toUint256(values ...*big.Int) (*uint256.Int, *uint256.Int, error) {
+	converted := make([]*uint256.Int, 2)
+	for k, value := range values {
+		if value == nil || value.Sign() < 0 {
+			return nil, nil, errors.New("expected a non-negative amount")
+		}
+		converted[k] = new(uint256.Int)
+		if converted[k].SetFromBig(value) {
+			return nil, nil, ErrOverflow
+		}
+	}
+	return converted[0], converted[1], nil
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"context"
+	"log"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"198/models"
+)
+
+// reorgWindow is the number of recent block hashes kept to detect reorgs
+const reorgWindow = 128
+
+// WatchHeads publishes every new block header on eventBus.NewHeads until ctx is cancelled.
+// A header that does not extend the previously seen chain publishes a ReorgEvent for the first replaced block first.
+// A failed subscription is retried with an exponential backoff (see Resubscribe).
+func WatchHeads(ctx context.Context, ethClient *ethclient.Client, eventBus *models.Bus) {
+	hashes := make(map[uint64]common.Hash) // Canonical hash by block number, as last seen
+	Resubscribe(ctx, "New heads", func() (bool, error) {
+		return watchHeads(ctx, ethClient, eventBus, hashes)
+	})
+}
+
+// watchHeads follows one new heads subscription until it fails, reporting whether it delivered any header
+func watchHeads(ctx context.Context, ethClient *ethclient.Client, eventBus *models.Bus, hashes map[uint64]common.Hash) (bool, error) {
+	headerChan := make(chan *types.Header)
+	subscription, err := ethClient.SubscribeNewHead(ctx, headerChan)
+	if err != nil {
+		return false, err
+	}
+	defer subscription.Unsubscribe()
+
+	received := false
+	for {
+		select {
+		case header := <-headerChan:
+			received = true
+			number := header.Number.Uint64()
+
+			// A different hash at a known height, or a parent we have not seen at the height below, means the chain was replaced
+			reorged := false
+			var reorg models.ReorgEvent
+			if old, ok := hashes[number]; ok && old != header.Hash() {
+				reorged, reorg = true, models.ReorgEvent{BlockNumber: number, OldHash: old, NewHash: header.Hash()}
+			}
+
+			// Walk back along the new chain until it meets a block we already know
+			parentHash := header.ParentHash
+			for n := number; n > 0; n-- {
+				known, ok := hashes[n-1]
+				if !ok || known == parentHash {
+					break
+				}
+				reorged, reorg = true, models.ReorgEvent{BlockNumber: n - 1, OldHash: known, NewHash: parentHash}
+				hashes[n-1] = parentHash
+				parent, err := ethClient.HeaderByHash(ctx, parentHash)
+				if err != nil {
+					log.Printf("Failed to fetch block header %v: %v", parentHash, err)
+					break
+				}
+				parentHash = parent.ParentHash
+			}
+			if reorged {
+				eventBus.Reorgs.Publish(reorg)
+			}
+
+			// Blocks above a shorter replacing chain are gone
+			for n := number + 1; ; n++ {
+				if _, ok := hashes[n]; !ok {
+					break
+				}
+				delete(hashes, n)
+			}
+			hashes[number] = header.Hash()
+			delete(hashes, number-reorgWindow)
+
+			eventBus.NewHeads.Publish(models.HeadEvent{
+				BlockNumber: number,
+				Hash:        header.Hash(),
+				ParentHash:  header.ParentHash,
+				Time:        header.Time,
+			})
+		case err := <-subscription.Err():
+			return received, err
+		case <-ctx.Done():
+			return received, ctx.Err()
+		}
+	}
+}
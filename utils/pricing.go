@@ -1,30 +1,58 @@
 package utils
 
 import (
-	"math"
 	"math/big"
 )
 
-// UniswapV3 CalculatePriceRatio computes the price ratio from sqrtPriceX96
-func CalculatePriceRatio(sqrtPriceX96 *big.Int) (*big.Float, error) {
-	sqrtPrice := new(big.Float).SetInt(sqrtPriceX96)
-	factor := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(2), big.NewInt(96), nil))
-	sqrtPriceQuotient := new(big.Float).Quo(sqrtPrice, factor)
-	priceRatio := new(big.Float).Mul(sqrtPriceQuotient, sqrtPriceQuotient)
+func FeeToFeePercentage(fee *big.Int) float64 {
+	feeFloat, _ := fee.Float64()
+	feePercentage := feeFloat / 1e6
+	return feePercentage
+}
+
+// GetAmountOutConstantProduct computes the exact output of a constant-product (UniswapV2-style) swap in raw token units.
+// The fee is in hundredths of a bip like pool fees elsewhere (3000 = 0.3%), so it matches UniswapV2Library.getAmountOut for the 997/1000 pairs.
+func GetAmountOutConstantProduct(amountIn, reserveIn, reserveOut, fee *big.Int) *big.Int {
+	if amountIn.Sign() <= 0 || reserveIn.Sign() <= 0 || reserveOut.Sign() <= 0 {
+		return big.NewInt(0)
+	}
+
+	feeDenominator := big.NewInt(1e6)
+	amountInWithFee := new(big.Int).Mul(amountIn, new(big.Int).Sub(feeDenominator, fee))
+	numerator := new(big.Int).Mul(amountInWithFee, reserveOut)
+	denominator := new(big.Int).Mul(reserveIn, feeDenominator)
+	denominator.Add(denominator, amountInWithFee)
 
-	return priceRatio, nil
+	return numerator.Quo(numerator, denominator)
 }
 
-// Adjust the price ratio considering the decimals of the tokens
-func AdjustForTokenDecimals(priceRatio *big.Float, decimalsToken0, decimalsToken1 int) *big.Float {
-	decimalsDifference := decimalsToken0 - decimalsToken1
-	factor := new(big.Float).SetFloat64(math.Pow10(decimalsDifference))
-	adjustedPrice := new(big.Float).Mul(priceRatio, factor)
-	return adjustedPrice
+// GetAmountInConstantProduct computes the raw input a constant-product pool needs to pay exactly amountOut, rounded up like UniswapV2Library.getAmountIn.
+// Returns nil if amountOut is not below reserveOut.
+func GetAmountInConstantProduct(amountOut, reserveIn, reserveOut, fee *big.Int) *big.Int {
+	if amountOut.Sign() <= 0 || reserveIn.Sign() <= 0 || amountOut.Cmp(reserveOut) >= 0 {
+		return nil
+	}
+
+	feeDenominator := big.NewInt(1e6)
+	numerator := new(big.Int).Mul(reserveIn, amountOut)
+	numerator.Mul(numerator, feeDenominator)
+	denominator := new(big.Int).Sub(reserveOut, amountOut)
+	denominator.Mul(denominator, new(big.Int).Sub(feeDenominator, fee))
+
+	amountIn := numerator.Quo(numerator, denominator)
+	return amountIn.Add(amountIn, big.NewInt(1))
 }
 
-func FeeToFeePercentage(fee *big.Int) float64 {
-	feeFloat, _ := fee.Float64()
-	feePercentage := feeFloat / 1e6
-	return feePercentage
+// VirtualReserves returns the raw reserves a constant-product pool would need to match a concentrated-liquidity position:
+// L / sqrtP of token0 and L * sqrtP of token1. They are only valid while the price stays within the current tick range.
+func VirtualReserves(sqrtPriceX96, liquidity *big.Int) (*big.Int, *big.Int) {
+	if sqrtPriceX96 == nil || liquidity == nil || sqrtPriceX96.Sign() == 0 {
+		return nil, nil
+	}
+	q96 := new(big.Int).Lsh(big.NewInt(1), 96)
+	reserve0 := new(big.Int).Mul(liquidity, q96)
+	reserve0.Quo(reserve0, sqrtPriceX96)
+	reserve1 := new(big.Int).Mul(liquidity, sqrtPriceX96)
+	reserve1.Quo(reserve1, q96)
+	return reserve0, reserve1
 }
@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"math/big"
+
+	"github.com/holiman/uint256"
+
+	"198/models"
+)
+
+// ConstantProductQuoter quotes a UniswapV2-style pair from its reserves.
+type ConstantProductQuoter struct {
+	Token0, Token1     *models.Token
+	Reserve0, Reserve1 *big.Int
+	Fee                *big.Int // Hundredths of a bip (3000 = 0.3%)
+}
+
+// AmountOut follows UniswapV2Library.getAmountOut.
+func (q ConstantProductQuoter) AmountOut(amountIn *models.Amount, tokenOut *models.Token) (*models.Amount, error) {
+	reserveIn, reserveOut, err := q.orient(amountIn.Token, tokenOut)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewAmount(tokenOut, GetAmountOutConstantProduct(amountIn.Raw, reserveIn, reserveOut, q.Fee)), nil
+}
+
+// AmountIn follows UniswapV2Library.getAmountIn.
+func (q ConstantProductQuoter) AmountIn(amountOut *models.Amount, tokenIn *models.Token) (*models.Amount, error) {
+	reserveIn, reserveOut, err := q.orient(tokenIn, amountOut.Token)
+	if err != nil {
+		return nil, err
+	}
+	amountIn := GetAmountInConstantProduct(amountOut.Raw, reserveIn, reserveOut, q.Fee)
+	if amountIn == nil {
+		return nil, models.ErrInsufficientLiquidity
+	}
+	return models.NewAmount(tokenIn, amountIn), nil
+}
+
+func (q ConstantProductQuoter) orient(tokenIn, tokenOut *models.Token) (*big.Int, *big.Int, error) {
+	switch {
+	case models.SameToken(tokenIn, q.Token0) && models.SameToken(tokenOut, q.Token1):
+		return q.Reserve0, q.Reserve1, nil
+	case models.SameToken(tokenIn, q.Token1) && models.SameToken(tokenOut, q.Token0):
+		return q.Reserve1, q.Reserve0, nil
+	}
+	return nil, nil, models.ErrTokenMismatch
+}
+
+// ConcentratedLiquidityQuoter quotes a V3-style pool (Uniswap V3/V4, Algebra) from its price and in-range liquidity.
+// The in-range liquidity only holds up to the next initialized tick: swaps that would cross it are not quoted
+// (ErrInsufficientLiquidity) rather than quoted on liquidity the pool may not have.
+type ConcentratedLiquidityQuoter struct {
+	Token0, Token1    *models.Token
+	SqrtPriceX96      *big.Int
+	Liquidity         *big.Int
+	SqrtPriceLowerX96 *big.Int // Price of the next initialized tick below (see InitializedTickRange), nil for MIN_SQRT_RATIO
+	SqrtPriceUpperX96 *big.Int // Price of the next initialized tick above, nil for MAX_SQRT_RATIO
+	Fee               *big.Int // Hundredths of a bip, the current dynamic fee for Algebra and V4 dynamic-fee pools
+	Algebra           bool     // Use Algebra's step (explicit direction)
+}
+
+// AmountOut mirrors exactInput: the whole input is consumed, or the quote fails if the next initialized tick is reached first.
+func (q ConcentratedLiquidityQuoter) AmountOut(amountIn *models.Amount, tokenOut *models.Token) (*models.Amount, error) {
+	zeroForOne, err := q.direction(amountIn.Token, tokenOut)
+	if err != nil {
+		return nil, err
+	}
+	step, err := q.step(zeroForOne, amountIn.Raw)
+	if err != nil {
+		return nil, err
+	}
+	if new(uint256.Int).Add(step.AmountIn, step.FeeAmount).ToBig().Cmp(amountIn.Raw) < 0 {
+		return nil, models.ErrInsufficientLiquidity
+	}
+	return models.NewAmount(tokenOut, step.AmountOut.ToBig()), nil
+}
+
+// AmountIn mirrors exactOutput: the input (fee included, rounded up) for exactly amountOut.
+func (q ConcentratedLiquidityQuoter) AmountIn(amountOut *models.Amount, tokenIn *models.Token) (*models.Amount, error) {
+	zeroForOne, err := q.direction(tokenIn, amountOut.Token)
+	if err != nil {
+		return nil, err
+	}
+	step, err := q.step(zeroForOne, new(big.Int).Neg(amountOut.Raw))
+	if err != nil {
+		return nil, err
+	}
+	if step.AmountOut.ToBig().Cmp(amountOut.Raw) < 0 {
+		return nil, models.ErrInsufficientLiquidity
+	}
+	return models.NewAmount(tokenIn, new(uint256.Int).Add(step.AmountIn, step.FeeAmount).ToBig()), nil
+}
+
+func (q ConcentratedLiquidityQuoter) direction(tokenIn, tokenOut *models.Token) (bool, error) {
+	switch {
+	case models.SameToken(tokenIn, q.Token0) && models.SameToken(tokenOut, q.Token1):
+		return true, nil
+	case models.SameToken(tokenIn, q.Token1) && models.SameToken(tokenOut, q.Token0):
+		return false, nil
+	}
+	return false, models.ErrTokenMismatch
+}
+
+// step swaps towards the next initialized tick, within the router's default price limit (MIN_SQRT_RATIO + 1 or MAX_SQRT_RATIO - 1)
+func (q ConcentratedLiquidityQuoter) step(zeroForOne bool, amountRemaining *big.Int) (SwapStep, error) {
+	sqrtPrice, liquidity, err := toUint256(q.SqrtPriceX96, q.Liquidity)
+	if err != nil {
+		return SwapStep{}, err
+	}
+	if liquidity.IsZero() {
+		return SwapStep{}, models.ErrInsufficientLiquidity
+	}
+	if q.Fee == nil || !q.Fee.IsUint64() {
+		return SwapStep{}, ErrInvalidFee
+	}
+	target := new(uint256.Int).AddUint64(MinSqrtRatio, 1)
+	bound := q.SqrtPriceLowerX96
+	if !zeroForOne {
+		target = new(uint256.Int).SubUint64(MaxSqrtRatio, 1)
+		bound = q.SqrtPriceUpperX96
+	}
+	if bound != nil {
+		tickPrice, overflow := uint256.FromBig(bound)
+		if overflow {
+			return SwapStep{}, ErrOverflow
+		}
+		if zeroForOne == tickPrice.Gt(target) {
+			target = tickPrice
+		}
+	}
+	// A stale range that does not contain the price leaves nothing to quote
+	if zeroForOne && target.Gt(sqrtPrice) || !zeroForOne && target.Lt(sqrtPrice) {
+		return SwapStep{}, models.ErrInsufficientLiquidity
+	}
+	if q.Algebra {
+		return ComputeSwapStepAlgebra(zeroForOne, sqrtPrice, target, liquidity, amountRemaining, q.Fee.Uint64())
+	}
+	return ComputeSwapStep(sqrtPrice, target, liquidity, amountRemaining, q.Fee.Uint64())
+}
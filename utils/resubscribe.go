@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+// maxResubscribeBackoff caps the wait between attempts to resubscribe
+const maxResubscribeBackoff = time.Minute
+
+// ErrUnwatchable marks a failure that resubscribing cannot fix, such as a pool that does not match its configuration.
+var ErrUnwatchable = errors.New("cannot be watched")
+
+// Resubscribe runs watch until ctx is cancelled, running it again whenever it fails: a subscription's Err channel
+// delivers one error and is closed, so a failed subscription has to be replaced rather than read again.
+// Attempts are spaced by an exponential backoff, reset once an attempt received anything (watch reports it).
+// An error wrapping ErrUnwatchable is logged and stops the retries. name prefixes the logged errors.
+func Resubscribe(ctx context.Context, name string, watch func() (received bool, err error)) {
+	backoff := time.Second
+	for {
+		received, err := watch()
+		if ctx.Err() != nil {
+			return
+		}
+		if errors.Is(err, ErrUnwatchable) {
+			log.Printf("ERROR: %v: %v", name, err)
+			return
+		}
+		if received {
+			backoff = time.Second
+		}
+		log.Printf("ERROR: %v subscription: %v (retrying in %v)", name, err, backoff)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		backoff = min(2*backoff, maxResubscribeBackoff)
+	}
+}
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestResubscribe(t *testing.T) {
+	// A failed attempt is retried, and cancelling ctx from within an attempt ends the retries
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	Resubscribe(ctx, "test", func() (bool, error) {
+		attempts++
+		if attempts == 2 {
+			cancel()
+			return true, ctx.Err()
+		}
+		return false, errors.New("subscription dropped")
+	})
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2", attempts)
+	}
+
+	// A failure retrying cannot fix stops at once
+	attempts = 0
+	Resubscribe(context.Background(), "test", func() (bool, error) {
+		attempts++
+		return false, fmt.Errorf("%w: token0 mismatch", ErrUnwatchable)
+	})
+	if attempts != 1 {
+		t.Errorf("unwatchable: attempts = %d, want 1", attempts)
+	}
+}
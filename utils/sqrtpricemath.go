@@ -0,0 +1,185 @@
+package utils
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/holiman/uint256"
+)
+
+// Port of Uniswap V3 SqrtPriceMath. Algebra's TokenDeltaMath and PriceMovementMath.getNewPrice are the same arithmetic.
+
+var (
+	maxUint160 = new(uint256.Int).Sub(new(uint256.Int).Lsh(uint256.NewInt(1), 160), uint256.NewInt(1))
+
+	ErrZeroLiquidity     = errors.New("liquidity must be positive")
+	ErrZeroSqrtPrice     = errors.New("sqrt price must be positive")
+	ErrInsufficientPrice = errors.New("amount exceeds the reserves at this price")
+)
+
+// GetNextSqrtPriceFromAmount0RoundingUp moves the price by amount of token0, added to or removed from the pool.
+// Rounds up so the price never moves further than the amount allows.
+func GetNextSqrtPriceFromAmount0RoundingUp(sqrtPriceX96, liquidity, amount *uint256.Int, add bool) (*uint256.Int, error) {
+	if amount.IsZero() {
+		return new(uint256.Int).Set(sqrtPriceX96), nil
+	}
+	numerator1 := new(uint256.Int).Lsh(liquidity, 96)
+	product, productOverflow := new(uint256.Int).MulOverflow(amount, sqrtPriceX96)
+
+	if add {
+		if !productOverflow {
+			if denominator, overflow := new(uint256.Int).AddOverflow(numerator1, product); !overflow {
+				return MulDivRoundingUp(numerator1, sqrtPriceX96, denominator)
+			}
+		}
+		// Always fits in 160 bits: numerator1 / (numerator1 / sqrtPrice + amount)
+		denominator, overflow := new(uint256.Int).AddOverflow(new(uint256.Int).Div(numerator1, sqrtPriceX96), amount)
+		if overflow {
+			return nil, ErrOverflow
+		}
+		return DivRoundingUp(numerator1, denominator)
+	}
+
+	if productOverflow || !numerator1.Gt(product) {
+		return nil, ErrInsufficientPrice
+	}
+	next, err := MulDivRoundingUp(numerator1, sqrtPriceX96, new(uint256.Int).Sub(numerator1, product))
+	if err != nil {
+		return nil, err
+	}
+	if next.Gt(maxUint160) {
+		return nil, ErrOverflow
+	}
+	return next, nil
+}
+
+// GetNextSqrtPriceFromAmount1RoundingDown moves the price by amount of token1, added to or removed from the pool.
+// Rounds down so the price never moves further than the amount allows.
+func GetNextSqrtPriceFromAmount1RoundingDown(sqrtPriceX96, liquidity, amount *uint256.Int, add bool) (*uint256.Int, error) {
+	if liquidity.IsZero() {
+		return nil, ErrZeroLiquidity
+	}
+	if add {
+		var quotient *uint256.Int
+		if !amount.Gt(maxUint160) {
+			quotient = new(uint256.Int).Lsh(amount, 96)
+			quotient.Div(quotient, liquidity)
+		} else {
+			var err error
+			if quotient, err = MulDiv(amount, Q96, liquidity); err != nil {
+				return nil, err
+			}
+		}
+		next, overflow := new(uint256.Int).AddOverflow(sqrtPriceX96, quotient)
+		if overflow || next.Gt(maxUint160) {
+			return nil, ErrOverflow
+		}
+		return next, nil
+	}
+
+	var quotient *uint256.Int
+	var err error
+	if !amount.Gt(maxUint160) {
+		quotient, err = DivRoundingUp(new(uint256.Int).Lsh(amount, 96), liquidity)
+	} else {
+		quotient, err = MulDivRoundingUp(amount, Q96, liquidity)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !sqrtPriceX96.Gt(quotient) {
+		return nil, ErrInsufficientPrice
+	}
+	return new(uint256.Int).Sub(sqrtPriceX96, quotient), nil
+}
+
+// GetNextSqrtPriceFromInput is the price after amountIn is swapped in (token0 if zeroForOne, token1 otherwise).
+func GetNextSqrtPriceFromInput(sqrtPriceX96, liquidity, amountIn *uint256.Int, zeroForOne bool) (*uint256.Int, error) {
+	if sqrtPriceX96.IsZero() {
+		return nil, ErrZeroSqrtPrice
+	}
+	if liquidity.IsZero() {
+		return nil, ErrZeroLiquidity
+	}
+	if zeroForOne {
+		return GetNextSqrtPriceFromAmount0RoundingUp(sqrtPriceX96, liquidity, amountIn, true)
+	}
+	return GetNextSqrtPriceFromAmount1RoundingDown(sqrtPriceX96, liquidity, amountIn, true)
+}
+
+// GetNextSqrtPriceFromOutput is the price after amountOut is swapped out (token1 if zeroForOne, token0 otherwise).
+func GetNextSqrtPriceFromOutput(sqrtPriceX96, liquidity, amountOut *uint256.Int, zeroForOne bool) (*uint256.Int, error) {
+	if sqrtPriceX96.IsZero() {
+		return nil, ErrZeroSqrtPrice
+	}
+	if liquidity.IsZero() {
+		return nil, ErrZeroLiquidity
+	}
+	if zeroForOne {
+		return GetNextSqrtPriceFromAmount1RoundingDown(sqrtPriceX96, liquidity, amountOut, false)
+	}
+	return GetNextSqrtPriceFromAmount0RoundingUp(sqrtPriceX96, liquidity, amountOut, false)
+}
+
+// GetAmount0Delta is the token0 amount between two prices for liquidity: L * (sqrtB - sqrtA) / (sqrtA * sqrtB).
+func GetAmount0Delta(sqrtRatioAX96, sqrtRatioBX96, liquidity *uint256.Int, roundUp bool) (*uint256.Int, error) {
+	if sqrtRatioAX96.Gt(sqrtRatioBX96) {
+		sqrtRatioAX96, sqrtRatioBX96 = sqrtRatioBX96, sqrtRatioAX96
+	}
+	if sqrtRatioAX96.IsZero() {
+		return nil, ErrZeroSqrtPrice
+	}
+	numerator1 := new(uint256.Int).Lsh(liquidity, 96)
+	numerator2 := new(uint256.Int).Sub(sqrtRatioBX96, sqrtRatioAX96)
+
+	if roundUp {
+		partial, err := MulDivRoundingUp(numerator1, numerator2, sqrtRatioBX96)
+		if err != nil {
+			return nil, err
+		}
+		return DivRoundingUp(partial, sqrtRatioAX96)
+	}
+	partial, err := MulDiv(numerator1, numerator2, sqrtRatioBX96)
+	if err != nil {
+		return nil, err
+	}
+	return partial.Div(partial, sqrtRatioAX96), nil
+}
+
+// GetAmount1Delta is the token1 amount between two prices for liquidity: L * (sqrtB - sqrtA).
+func GetAmount1Delta(sqrtRatioAX96, sqrtRatioBX96, liquidity *uint256.Int, roundUp bool) (*uint256.Int, error) {
+	if sqrtRatioAX96.Gt(sqrtRatioBX96) {
+		sqrtRatioAX96, sqrtRatioBX96 = sqrtRatioBX96, sqrtRatioAX96
+	}
+	difference := new(uint256.Int).Sub(sqrtRatioBX96, sqrtRatioAX96)
+	if roundUp {
+		return MulDivRoundingUp(liquidity, difference, Q96)
+	}
+	return MulDiv(liquidity, difference, Q96)
+}
+
+// GetAmount0DeltaSigned is the token0 owed to (positive) or by (negative) the pool when liquidityDelta is added or removed.
+func GetAmount0DeltaSigned(sqrtRatioAX96, sqrtRatioBX96 *uint256.Int, liquidityDelta *big.Int) (*big.Int, error) {
+	return signedDelta(GetAmount0Delta, sqrtRatioAX96, sqrtRatioBX96, liquidityDelta)
+}
+
+// GetAmount1DeltaSigned is the token1 counterpart of GetAmount0DeltaSigned.
+func GetAmount1DeltaSigned(sqrtRatioAX96, sqrtRatioBX96 *uint256.Int, liquidityDelta *big.Int) (*big.Int, error) {
+	return signedDelta(GetAmount1Delta, sqrtRatioAX96, sqrtRatioBX96, liquidityDelta)
+}
+
+// signedDelta rounds up amounts paid into the pool and down amounts paid out of it
+func signedDelta(delta func(a, b, liquidity *uint256.Int, roundUp bool) (*uint256.Int, error), sqrtRatioAX96, sqrtRatioBX96 *uint256.Int, liquidityDelta *big.Int) (*big.Int, error) {
+	liquidity, overflow := uint256.FromBig(new(big.Int).Abs(liquidityDelta))
+	if overflow {
+		return nil, ErrOverflow
+	}
+	amount, err := delta(sqrtRatioAX96, sqrtRatioBX96, liquidity, liquidityDelta.Sign() >= 0)
+	if err != nil {
+		return nil, err
+	}
+	if liquidityDelta.Sign() < 0 {
+		return new(big.Int).Neg(amount.ToBig()), nil
+	}
+	return amount.ToBig(), nil
+}
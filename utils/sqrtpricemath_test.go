@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/holiman/uint256"
+)
+
+// Vectors from the Uniswap v3-core SqrtPriceMath spec, at a price of one (2^96) unless stated otherwise
+var (
+	priceOne   = uint256.MustFromDecimal("79228162514264337593543950336")
+	oneEther   = uint256.NewInt(1e18)
+	tenthEther = uint256.NewInt(1e17)
+)
+
+func TestGetNextSqrtPriceFromInput(t *testing.T) {
+	maxHalf := new(uint256.Int).Rsh(new(uint256.Int).SetAllOne(), 1)
+	vectors := []struct {
+		name                           string
+		sqrtPriceX96, liquidity, input *uint256.Int
+		zeroForOne                     bool
+		expected                       string
+	}{
+		{"token1 in", priceOne, oneEther, tenthEther, false, "87150978765690771352898345369"},
+		{"token0 in", priceOne, oneEther, tenthEther, true, "72025602285694852357767227579"},
+		{"token0 in, amount above 2^96", priceOne, uint256.NewInt(10e18), new(uint256.Int).Lsh(uint256.NewInt(1), 100), true, "624999999995069620"},
+		{"token0 in, price bottoms out", priceOne, uint256.NewInt(1), maxHalf, true, "1"},
+		{"zero amount, token0", priceOne, tenthEther, new(uint256.Int), true, priceOne.Dec()},
+		{"zero amount, token1", priceOne, tenthEther, new(uint256.Int), false, priceOne.Dec()},
+	}
+	for _, vector := range vectors {
+		next, err := GetNextSqrtPriceFromInput(vector.sqrtPriceX96, vector.liquidity, vector.input, vector.zeroForOne)
+		if err != nil {
+			t.Fatalf("%v: %v", vector.name, err)
+		}
+		if next.Dec() != vector.expected {
+			t.Errorf("%v: got %v, want %v", vector.name, next.Dec(), vector.expected)
+		}
+	}
+
+	if _, err := GetNextSqrtPriceFromInput(new(uint256.Int), oneEther, tenthEther, false); err != ErrZeroSqrtPrice {
+		t.Errorf("zero price: got %v, want ErrZeroSqrtPrice", err)
+	}
+	if _, err := GetNextSqrtPriceFromInput(priceOne, new(uint256.Int), tenthEther, true); err != ErrZeroLiquidity {
+		t.Errorf("zero liquidity: got %v, want ErrZeroLiquidity", err)
+	}
+}
+
+func TestGetNextSqrtPriceFromOutput(t *testing.T) {
+	vectors := []struct {
+		name                            string
+		sqrtPriceX96, liquidity, output *uint256.Int
+		zeroForOne                      bool
+		expected                        string
+	}{
+		{"token1 out", priceOne, oneEther, tenthEther, true, "71305346262837903834189555302"},
+		{"token0 out", priceOne, oneEther, tenthEther, false, "88031291682515930659493278152"},
+		{"just below the virtual reserves", uint256.MustFromDecimal("20282409603651670423947251286016"), uint256.NewInt(1024), uint256.NewInt(262143), true, "77371252455336267181195264"},
+		{"zero amount", priceOne, tenthEther, new(uint256.Int), true, priceOne.Dec()},
+	}
+	for _, vector := range vectors {
+		next, err := GetNextSqrtPriceFromOutput(vector.sqrtPriceX96, vector.liquidity, vector.output, vector.zeroForOne)
+		if err != nil {
+			t.Fatalf("%v: %v", vector.name, err)
+		}
+		if next.Dec() != vector.expected {
+			t.Errorf("%v: got %v, want %v", vector.name, next.Dec(), vector.expected)
+		}
+	}
+
+	// The virtual reserves at this price are 4 token0 and 262144 token1, neither can be taken out in full
+	price := uint256.MustFromDecimal("20282409603651670423947251286016")
+	if _, err := GetNextSqrtPriceFromOutput(price, uint256.NewInt(1024), uint256.NewInt(4), false); err == nil {
+		t.Error("token0 out equal to the reserves: want an error")
+	}
+	if _, err := GetNextSqrtPriceFromOutput(price, uint256.NewInt(1024), uint256.NewInt(262144), true); err == nil {
+		t.Error("token1 out equal to the reserves: want an error")
+	}
+}
+
+func TestGetAmountDeltas(t *testing.T) {
+	next := uint256.MustFromDecimal("87150978765690771352898345369")
+	vectors := []struct {
+		name     string
+		delta    func(a, b, liquidity *uint256.Int, roundUp bool) (*uint256.Int, error)
+		roundUp  bool
+		expected string
+	}{
+		{"amount0 rounded up", GetAmount0Delta, true, "90909090909090910"},
+		{"amount0 rounded down", GetAmount0Delta, false, "90909090909090909"},
+		{"amount1 rounded up", GetAmount1Delta, true, "100000000000000000"},
+		{"amount1 rounded down", GetAmount1Delta, false, "99999999999999999"},
+	}
+	for _, vector := range vectors {
+		// The prices may come in either order
+		for _, bounds := range [][2]*uint256.Int{{priceOne, next}, {next, priceOne}} {
+			amount, err := vector.delta(bounds[0], bounds[1], oneEther, vector.roundUp)
+			if err != nil {
+				t.Fatalf("%v: %v", vector.name, err)
+			}
+			if amount.Dec() != vector.expected {
+				t.Errorf("%v: got %v, want %v", vector.name, amount.Dec(), vector.expected)
+			}
+		}
+	}
+
+	for _, delta := range []func(a, b, liquidity *uint256.Int, roundUp bool) (*uint256.Int, error){GetAmount0Delta, GetAmount1Delta} {
+		if amount, err := delta(priceOne, priceOne, oneEther, true); err != nil || !amount.IsZero() {
+			t.Errorf("equal prices: got %v (%v), want 0", amount, err)
+		}
+		if amount, err := delta(priceOne, next, new(uint256.Int), true); err != nil || !amount.IsZero() {
+			t.Errorf("zero liquidity: got %v (%v), want 0", amount, err)
+		}
+	}
+}
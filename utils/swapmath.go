@@ -0,0 +1,116 @@
+package utils
+
+import (
+	"math/big"
+
+	"github.com/holiman/uint256"
+)
+
+// SwapStep is the result of swapping within a single initialized-tick range.
+type SwapStep struct {
+	SqrtRatioNextX96 *uint256.Int // Price after the step
+	AmountIn         *uint256.Int // Input consumed, fee excluded
+	AmountOut        *uint256.Int
+	FeeAmount        *uint256.Int
+}
+
+// ComputeSwapStep is a port of Uniswap V3 SwapMath.computeSwapStep.
+// amountRemaining is positive for exact input and negative for exact output; the direction follows from the current and target prices.
+func ComputeSwapStep(sqrtRatioCurrentX96, sqrtRatioTargetX96, liquidity *uint256.Int, amountRemaining *big.Int, feePips uint64) (SwapStep, error) {
+	zeroForOne := !sqrtRatioCurrentX96.Lt(sqrtRatioTargetX96)
+	return computeSwapStep(zeroForOne, sqrtRatioCurrentX96, sqrtRatioTargetX96, liquidity, amountRemaining, feePips)
+}
+
+// ComputeSwapStepAlgebra is Algebra's PriceMovementMath.movePriceTowardsTarget.
+// The arithmetic matches ComputeSwapStep but the direction is given explicitly (a step with current == target keeps it),
+// and feePips is the pool's current dynamic fee rather than a fixed tier.
+func ComputeSwapStepAlgebra(zeroToOne bool, sqrtRatioCurrentX96, sqrtRatioTargetX96, liquidity *uint256.Int, amountAvailable *big.Int, feePips uint64) (SwapStep, error) {
+	return computeSwapStep(zeroToOne, sqrtRatioCurrentX96, sqrtRatioTargetX96, liquidity, amountAvailable, feePips)
+}
+
+func computeSwapStep(zeroForOne bool, sqrtRatioCurrentX96, sqrtRatioTargetX96, liquidity *uint256.Int, amountRemaining *big.Int, feePips uint64) (SwapStep, error) {
+	if feePips >= FeeDenominator {
+		return SwapStep{}, ErrInvalidFee
+	}
+	exactIn := amountRemaining.Sign() >= 0
+	remaining, overflow := uint256.FromBig(new(big.Int).Abs(amountRemaining))
+	if overflow {
+		return SwapStep{}, ErrOverflow
+	}
+
+	// Amount needed to reach the target (input rounded up, output rounded down) or the price reached before it
+	var step SwapStep
+	var err error
+	if exactIn {
+		amountRemainingLessFee, err := AmountLessFee(remaining, feePips)
+		if err != nil {
+			return SwapStep{}, err
+		}
+		if zeroForOne {
+			step.AmountIn, err = GetAmount0Delta(sqrtRatioTargetX96, sqrtRatioCurrentX96, liquidity, true)
+		} else {
+			step.AmountIn, err = GetAmount1Delta(sqrtRatioCurrentX96, sqrtRatioTargetX96, liquidity, true)
+		}
+		if err != nil {
+			return SwapStep{}, err
+		}
+		if !amountRemainingLessFee.Lt(step.AmountIn) {
+			step.SqrtRatioNextX96 = new(uint256.Int).Set(sqrtRatioTargetX96)
+		} else if step.SqrtRatioNextX96, err = GetNextSqrtPriceFromInput(sqrtRatioCurrentX96, liquidity, amountRemainingLessFee, zeroForOne); err != nil {
+			return SwapStep{}, err
+		}
+	} else {
+		if zeroForOne {
+			step.AmountOut, err = GetAmount1Delta(sqrtRatioTargetX96, sqrtRatioCurrentX96, liquidity, false)
+		} else {
+			step.AmountOut, err = GetAmount0Delta(sqrtRatioCurrentX96, sqrtRatioTargetX96, liquidity, false)
+		}
+		if err != nil {
+			return SwapStep{}, err
+		}
+		if !remaining.Lt(step.AmountOut) {
+			step.SqrtRatioNextX96 = new(uint256.Int).Set(sqrtRatioTargetX96)
+		} else if step.SqrtRatioNextX96, err = GetNextSqrtPriceFromOutput(sqrtRatioCurrentX96, liquidity, remaining, zeroForOne); err != nil {
+			return SwapStep{}, err
+		}
+	}
+
+	// Amounts actually swapped when the target was not reached
+	reachedTarget := step.SqrtRatioNextX96.Eq(sqrtRatioTargetX96)
+	if zeroForOne {
+		if !(reachedTarget && exactIn) {
+			if step.AmountIn, err = GetAmount0Delta(step.SqrtRatioNextX96, sqrtRatioCurrentX96, liquidity, true); err != nil {
+				return SwapStep{}, err
+			}
+		}
+		if !(reachedTarget && !exactIn) {
+			if step.AmountOut, err = GetAmount1Delta(step.SqrtRatioNextX96, sqrtRatioCurrentX96, liquidity, false); err != nil {
+				return SwapStep{}, err
+			}
+		}
+	} else {
+		if !(reachedTarget && exactIn) {
+			if step.AmountIn, err = GetAmount1Delta(sqrtRatioCurrentX96, step.SqrtRatioNextX96, liquidity, true); err != nil {
+				return SwapStep{}, err
+			}
+		}
+		if !(reachedTarget && !exactIn) {
+			if step.AmountOut, err = GetAmount0Delta(sqrtRatioCurrentX96, step.SqrtRatioNextX96, liquidity, false); err != nil {
+				return SwapStep{}, err
+			}
+		}
+	}
+
+	// Output cannot exceed what was asked for
+	if !exactIn && step.AmountOut.Gt(remaining) {
+		step.AmountOut = new(uint256.Int).Set(remaining)
+	}
+
+	// Exact input that stopped short of the target: the whole remainder beyond AmountIn is the fee
+	if exactIn && !reachedTarget {
+		step.FeeAmount = new(uint256.Int).Sub(remaining, step.AmountIn)
+	} else if step.FeeAmount, err = MulDivRoundingUp(step.AmountIn, uint256.NewInt(feePips), uint256.NewInt(FeeDenominator-feePips)); err != nil {
+		return SwapStep{}, err
+	}
+	return step, nil
+}
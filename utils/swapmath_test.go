@@ -0,0 +1,137 @@
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+)
+
+func TestComputeSwapStep(t *testing.T) {
+	// Vectors from the Uniswap v3-core SwapMath spec
+	vectors := []struct {
+		name                                  string
+		current, target, liquidity, remaining string
+		feePips                               uint64
+		next, amountIn, amountOut, feeAmount  string
+	}{
+		{"exact in capped at the target, one for zero",
+			"79228162514264337593543950336", "79623317895830914510639640423", "2000000000000000000", "1000000000000000000", 600,
+			"79623317895830914510639640423", "9975124224178055", "9925619580021728", "5988667735148"},
+		{"exact out capped at the target, one for zero",
+			"79228162514264337593543950336", "79623317895830914510639640423", "2000000000000000000", "-1000000000000000000", 600,
+			"79623317895830914510639640423", "9975124224178055", "9925619580021728", "5988667735148"},
+		{"exact in fully spent, one for zero",
+			"79228162514264337593543950336", "250541448375047931186413801569", "2000000000000000000", "1000000000000000000", 600,
+			"", "999400000000000000", "666399946655997866", "600000000000000"},
+		{"exact out fully received, one for zero",
+			"79228162514264337593543950336", "792281625142643375935439503360", "2000000000000000000", "-1000000000000000000", 600,
+			"", "2000000000000000000", "1000000000000000000", "1200720432259356"},
+		{"amount out capped at the desired amount",
+			"417332158212080721273783715441582", "1452870262520218020823638996", "159344665391607089467575320103", "-1", 1,
+			"417332158212080721273783715441581", "1", "1", "1"},
+		{"target price of one uses the partial input",
+			"2", "1", "1", "3915081100057732413702495386755767", 1,
+			"1", "39614081257132168796771975168", "0", "39614120871253040049813"},
+		{"entire input taken as the fee",
+			"2413", "79887613182836312", "1985041575832132834610021537970", "10", 1872,
+			"2413", "0", "0", "10"},
+		{"insufficient liquidity, zero for one, exact out",
+			"20282409603651670423947251286016", "22310650564016837466341976414617", "1024", "-4", 3000,
+			"", "26215", "0", "79"},
+		{"insufficient liquidity, one for zero, exact out",
+			"20282409603651670423947251286016", "18254168643286503381552526157414", "1024", "-263000", 3000,
+			"", "1", "26214", "1"},
+	}
+	for _, vector := range vectors {
+		remaining, _ := new(big.Int).SetString(vector.remaining, 10)
+		step, err := ComputeSwapStep(uint256.MustFromDecimal(vector.current), uint256.MustFromDecimal(vector.target), uint256.MustFromDecimal(vector.liquidity), remaining, vector.feePips)
+		if err != nil {
+			t.Fatalf("%v: %v", vector.name, err)
+		}
+		if vector.next != "" && step.SqrtRatioNextX96.Dec() != vector.next {
+			t.Errorf("%v: next price %v, want %v", vector.name, step.SqrtRatioNextX96.Dec(), vector.next)
+		}
+		if step.AmountIn.Dec() != vector.amountIn || step.AmountOut.Dec() != vector.amountOut || step.FeeAmount.Dec() != vector.feeAmount {
+			t.Errorf("%v: got in %v out %v fee %v, want in %v out %v fee %v", vector.name,
+				step.AmountIn.Dec(), step.AmountOut.Dec(), step.FeeAmount.Dec(), vector.amountIn, vector.amountOut, vector.feeAmount)
+		}
+	}
+
+	if _, err := ComputeSwapStep(priceOne, priceOne, oneEther, big.NewInt(1), FeeDenominator); err != ErrInvalidFee {
+		t.Errorf("fee of 100%%: got %v, want ErrInvalidFee", err)
+	}
+}
+
+func TestComputeSwapStepFeeTiers(t *testing.T) {
+	// A step that stops short of the target charges the remainder as fee, one that reaches it charges fee/(1e6-fee) of the input
+	target := uint256.MustFromDecimal("79623317895830914510639640423")
+	liquidity := uint256.NewInt(2e18)
+	for _, feePips := range []uint64{100, 500, 3000, 10000} {
+		for _, amount := range []int64{1e15, 1e18} {
+			step, err := ComputeSwapStep(priceOne, target, liquidity, big.NewInt(amount), feePips)
+			if err != nil {
+				t.Fatalf("fee %v: %v", feePips, err)
+			}
+			spent := new(uint256.Int).Add(step.AmountIn, step.FeeAmount)
+			if step.SqrtRatioNextX96.Eq(target) {
+				expected, _ := MulDivRoundingUp(step.AmountIn, uint256.NewInt(feePips), uint256.NewInt(FeeDenominator-feePips))
+				if !step.FeeAmount.Eq(expected) || spent.Gt(uint256.NewInt(uint64(amount))) {
+					t.Errorf("fee %v, amount %v: in %v fee %v overspend or misprice the fee", feePips, amount, step.AmountIn.Dec(), step.FeeAmount.Dec())
+				}
+			} else if !spent.Eq(uint256.NewInt(uint64(amount))) {
+				t.Errorf("fee %v, amount %v: spent %v, want the whole amount", feePips, amount, spent.Dec())
+			}
+
+			// Algebra's step is the same arithmetic with the direction given explicitly
+			algebra, err := ComputeSwapStepAlgebra(false, priceOne, target, liquidity, big.NewInt(amount), feePips)
+			if err != nil {
+				t.Fatalf("fee %v: %v", feePips, err)
+			}
+			if !algebra.SqrtRatioNextX96.Eq(step.SqrtRatioNextX96) || !algebra.AmountIn.Eq(step.AmountIn) || !algebra.AmountOut.Eq(step.AmountOut) || !algebra.FeeAmount.Eq(step.FeeAmount) {
+				t.Errorf("fee %v, amount %v: Algebra step differs from the Uniswap step", feePips, amount)
+			}
+		}
+	}
+}
+
+// FuzzComputeSwapStep checks that a step never spends more than the exact input nor returns more than the exact output.
+func FuzzComputeSwapStep(f *testing.F) {
+	f.Add(uint64(1<<32), uint64(1<<33), uint64(2e18), int64(1e18), uint32(600))
+	f.Add(uint64(1<<40), uint64(1<<35), uint64(1024), int64(-4), uint32(3000))
+	f.Add(uint64(2413), uint64(79887613182836312), uint64(1e15), int64(10), uint32(1872))
+	f.Fuzz(func(t *testing.T, current, target, liquidity uint64, remaining int64, fee uint32) {
+		if current == 0 || target == 0 || liquidity == 0 || uint64(fee) >= FeeDenominator {
+			return
+		}
+		// Scale the prices into the usual range so both directions are exercised
+		currentX96 := new(uint256.Int).Lsh(uint256.NewInt(current), 64)
+		targetX96 := new(uint256.Int).Lsh(uint256.NewInt(target), 64)
+		step, err := ComputeSwapStep(currentX96, targetX96, uint256.NewInt(liquidity), big.NewInt(remaining), uint64(fee))
+		if err != nil {
+			// Exact outputs beyond the virtual reserves are rejected
+			return
+		}
+
+		magnitude := new(uint256.Int).SetUint64(uint64(remaining))
+		if remaining < 0 {
+			magnitude.SetUint64(uint64(-remaining))
+		}
+		if remaining >= 0 {
+			if spent := new(uint256.Int).Add(step.AmountIn, step.FeeAmount); spent.Gt(magnitude) {
+				t.Fatalf("exact in %v: spent %v", remaining, spent.Dec())
+			}
+		} else if step.AmountOut.Gt(magnitude) {
+			t.Fatalf("exact out %v: received %v", remaining, step.AmountOut.Dec())
+		}
+
+		// The price moves towards the target and never past it
+		if zeroForOne := !currentX96.Lt(targetX96); zeroForOne {
+			if step.SqrtRatioNextX96.Gt(currentX96) || step.SqrtRatioNextX96.Lt(targetX96) {
+				t.Fatalf("zero for one: next price %v outside [%v, %v]", step.SqrtRatioNextX96.Dec(), targetX96.Dec(), currentX96.Dec())
+			}
+		} else if step.SqrtRatioNextX96.Lt(currentX96) || step.SqrtRatioNextX96.Gt(targetX96) {
+			t.Fatalf("one for zero: next price %v outside [%v, %v]", step.SqrtRatioNextX96.Dec(), currentX96.Dec(), targetX96.Dec())
+		}
+	})
+}
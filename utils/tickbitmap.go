@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"errors"
+	"math/big"
+)
+
+// Port of Uniswap V3 TickBitmap. Algebra's tickTable packs the ticks the same way.
+
+var ErrInvalidTickSpacing = errors.New("tick spacing must be positive")
+
+// TickBitmapPosition is the bitmap word and bit of a tick compressed by the tick spacing (TickBitmap.position).
+func TickBitmapPosition(compressed int) (int16, uint) {
+	return int16(compressed >> 8), uint(compressed & 0xff)
+}
+
+// NextInitializedTickWithinOneWord returns the next initialized tick at or below tick (lte) or above it, looking only at
+// the bitmap word that contains it (TickBitmap.nextInitializedTickWithinOneWord). readWord returns the word at a position.
+// When the word holds no initialized tick in that direction, its last tick is returned and initialized is false.
+func NextInitializedTickWithinOneWord(readWord func(int16) (*big.Int, error), tick, tickSpacing int, lte bool) (next int, initialized bool, err error) {
+	if tickSpacing <= 0 {
+		return 0, false, ErrInvalidTickSpacing
+	}
+	compressed := tick / tickSpacing
+	if tick < 0 && tick%tickSpacing != 0 {
+		compressed-- // Round towards negative infinity
+	}
+
+	if lte {
+		wordPos, bitPos := TickBitmapPosition(compressed)
+		word, err := readWord(wordPos)
+		if err != nil {
+			return 0, false, err
+		}
+		// All the bits at or to the right of bitPos
+		mask := new(big.Int).Lsh(big.NewInt(1), bitPos+1)
+		masked := mask.And(word, mask.Sub(mask, big.NewInt(1)))
+		if masked.Sign() != 0 {
+			return (compressed - int(bitPos) + masked.BitLen() - 1) * tickSpacing, true, nil
+		}
+		return (compressed - int(bitPos)) * tickSpacing, false, nil
+	}
+
+	wordPos, bitPos := TickBitmapPosition(compressed + 1)
+	word, err := readWord(wordPos)
+	if err != nil {
+		return 0, false, err
+	}
+	// All the bits at or to the left of bitPos
+	masked := new(big.Int).Rsh(word, bitPos)
+	if masked.Sign() != 0 {
+		return (compressed + 1 + int(masked.TrailingZeroBits())) * tickSpacing, true, nil
+	}
+	return (compressed + 1 + 255 - int(bitPos)) * tickSpacing, false, nil
+}
+
+// InitializedTickRange returns the prices of the ticks around tick up to which the in-range liquidity is known to hold:
+// the next initialized tick (or word boundary) in each direction, bounded by MinTick and MaxTick.
+// Both words are read at most once.
+func InitializedTickRange(readWord func(int16) (*big.Int, error), tick, tickSpacing int) (*big.Int, *big.Int, error) {
+	words := make(map[int16]*big.Int)
+	cachedWord := func(wordPos int16) (*big.Int, error) {
+		if word, ok := words[wordPos]; ok {
+			return word, nil
+		}
+		word, err := readWord(wordPos)
+		if err != nil {
+			return nil, err
+		}
+		words[wordPos] = word
+		return word, nil
+	}
+
+	lower, _, err := NextInitializedTickWithinOneWord(cachedWord, tick, tickSpacing, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	upper, _, err := NextInitializedTickWithinOneWord(cachedWord, tick, tickSpacing, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tickRangePrices(lower, upper)
+}
+
+// TickSpacingRange returns the prices of the tick-spacing multiples around tick. Only those ticks can be initialized,
+// so the in-range liquidity holds between them whatever the bitmap says.
+func TickSpacingRange(tick, tickSpacing int) (*big.Int, *big.Int, error) {
+	if tickSpacing <= 0 {
+		return nil, nil, ErrInvalidTickSpacing
+	}
+	lower := tick / tickSpacing * tickSpacing
+	if tick < 0 && tick%tickSpacing != 0 {
+		lower -= tickSpacing
+	}
+	return tickRangePrices(lower, lower+tickSpacing)
+}
+
+func tickRangePrices(lower, upper int) (*big.Int, *big.Int, error) {
+	lower, upper = max(lower, MinTick), min(upper, MaxTick)
+	sqrtPriceLower, err := GetSqrtRatioAtTick(lower)
+	if err != nil {
+		return nil, nil, err
+	}
+	sqrtPriceUpper, err := GetSqrtRatioAtTick(upper)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sqrtPriceLower.ToBig(), sqrtPriceUpper.ToBig(), nil
+}
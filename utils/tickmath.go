@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"errors"
+	"math"
+
+	"github.com/holiman/uint256"
+)
+
+// Port of Uniswap V3 TickMath. Algebra's TickMath uses the same bounds and constants.
+
+const (
+	MinTick = -887272
+	MaxTick = 887272
+)
+
+var (
+	// MinSqrtRatio is getSqrtRatioAtTick(MinTick)
+	MinSqrtRatio = uint256.NewInt(4295128739)
+	// MaxSqrtRatio is getSqrtRatioAtTick(MaxTick)
+	MaxSqrtRatio = uint256.MustFromDecimal("1461446703485210103287273052203988822378723970342")
+
+	ErrTickOutOfRange        = errors.New("tick out of range")
+	ErrSqrtRatioOutOfRange   = errors.New("sqrt ratio out of range")
+	maxUint256               = new(uint256.Int).SetAllOne()
+	sqrtRatioTickMultipliers = []*uint256.Int{
+		uint256.MustFromHex("0xfff97272373d413259a46990580e213a"),
+		uint256.MustFromHex("0xfff2e50f5f656932ef12357cf3c7fdcc"),
+		uint256.MustFromHex("0xffe5caca7e10e4e61c3624eaa0941cd0"),
+		uint256.MustFromHex("0xffcb9843d60f6159c9db58835c926644"),
+		uint256.MustFromHex("0xff973b41fa98c081472e6896dfb254c0"),
+		uint256.MustFromHex("0xff2ea16466c96a3843ec78b326b52861"),
+		uint256.MustFromHex("0xfe5dee046a99a2a811c461f1969c3053"),
+		uint256.MustFromHex("0xfcbe86c7900a88aedcffc83b479aa3a4"),
+		uint256.MustFromHex("0xf987a7253ac413176f2b074cf7815e54"),
+		uint256.MustFromHex("0xf3392b0822b70005940c7a398e4b70f3"),
+		uint256.MustFromHex("0xe7159475a2c29b7443b29c7fa6e889d9"),
+		uint256.MustFromHex("0xd097f3bdfd2022b8845ad8f792aa5825"),
+		uint256.MustFromHex("0xa9f746462d870fdf8a65dc1f90e061e5"),
+		uint256.MustFromHex("0x70d869a156d2a1b890bb3df62baf32f7"),
+		uint256.MustFromHex("0x31be135f97d08fd981231505542fcfa6"),
+		uint256.MustFromHex("0x9aa508b5b7a84e1c677de54f3e99bc9"),
+		uint256.MustFromHex("0x5d6af8dedb81196699c329225ee604"),
+		uint256.MustFromHex("0x2216e584f5fa1ea926041bedfe98"),
+		uint256.MustFromHex("0x48a170391f7dc42444e8fa2"),
+	}
+)
+
+// GetSqrtRatioAtTick returns sqrt(1.0001^tick) as a Q64.96, rounded up (TickMath.getSqrtRatioAtTick).
+func GetSqrtRatioAtTick(tick int) (*uint256.Int, error) {
+	absTick := tick
+	if absTick < 0 {
+		absTick = -absTick
+	}
+	if absTick > MaxTick {
+		return nil, ErrTickOutOfRange
+	}
+
+	ratio := new(uint256.Int).Lsh(uint256.NewInt(1), 128)
+	if absTick&0x1 != 0 {
+		ratio = uint256.MustFromHex("0xfffcb933bd6fad37aa2d162d1a594001")
+	}
+	for k, multiplier := range sqrtRatioTickMultipliers {
+		if absTick&(0x2<<k) != 0 {
+			// ratio * multiplier fits in 256 bits as both are below 2^128
+			ratio.Mul(ratio, multiplier).Rsh(ratio, 128)
+		}
+	}
+	if tick > 0 {
+		ratio.Div(maxUint256, ratio)
+	}
+
+	// Q128.128 -> Q64.96, rounding up so that getTickAtSqrtRatio of the result is tick
+	sqrtPriceX96 := new(uint256.Int).Rsh(ratio, 32)
+	if new(uint256.Int).And(ratio, uint256.NewInt(math.MaxUint32)).Sign() != 0 {
+		sqrtPriceX96.AddUint64(sqrtPriceX96, 1)
+	}
+	return sqrtPriceX96, nil
+}
+
+// GetTickAtSqrtRatio returns the greatest tick whose sqrt ratio is at most sqrtPriceX96 (TickMath.getTickAtSqrtRatio).
+// The Solidity version approximates log2 bit by bit; here a float estimate is corrected against GetSqrtRatioAtTick, which yields the same tick.
+func GetTickAtSqrtRatio(sqrtPriceX96 *uint256.Int) (int, error) {
+	if sqrtPriceX96.Lt(MinSqrtRatio) || !sqrtPriceX96.Lt(MaxSqrtRatio) {
+		return 0, ErrSqrtRatioOutOfRange
+	}
+	sqrtPrice := sqrtPriceX96.Float64() / math.Exp2(96)
+	tick := int(math.Floor(2 * math.Log(sqrtPrice) / math.Log(1.0001)))
+	if tick < MinTick {
+		tick = MinTick
+	}
+	if tick > MaxTick {
+		tick = MaxTick
+	}
+
+	for tick > MinTick {
+		ratio, _ := GetSqrtRatioAtTick(tick)
+		if !ratio.Gt(sqrtPriceX96) {
+			break
+		}
+		tick--
+	}
+	for tick < MaxTick {
+		ratio, _ := GetSqrtRatioAtTick(tick + 1)
+		if ratio.Gt(sqrtPriceX96) {
+			break
+		}
+		tick++
+	}
+	return tick, nil
+}
@@ -0,0 +1,161 @@
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+)
+
+// exactSqrtRatioAtTick computes sqrt(1.0001^tick) * 2^96 in high precision, independently of the TickMath constants
+func exactSqrtRatioAtTick(tick int) *big.Float {
+	const precision = 512
+	// 1.0001 as 10001/10000, a float64 literal is off in the 17th digit
+	base := new(big.Float).SetPrec(precision).SetInt64(10001)
+	base.Quo(base, new(big.Float).SetPrec(precision).SetInt64(10000))
+	if tick < 0 {
+		base.Quo(new(big.Float).SetPrec(precision).SetInt64(1), base)
+		tick = -tick
+	}
+	base.Sqrt(base)
+	result := new(big.Float).SetPrec(precision).SetInt64(1)
+	for ; tick > 0; tick >>= 1 {
+		if tick&1 != 0 {
+			result.Mul(result, base)
+		}
+		base.Mul(base, base)
+	}
+	return result.SetMantExp(result, 96)
+}
+
+func TestGetSqrtRatioAtTick(t *testing.T) {
+	// Vectors from the Uniswap v3-core TickMath spec
+	vectors := []struct {
+		tick     int
+		expected string
+	}{
+		{MinTick, "4295128739"},
+		{MinTick + 1, "4295343490"},
+		{0, "79228162514264337593543950336"},
+		{MaxTick - 1, "1461373636630004318706518188784493106690254656249"},
+		{MaxTick, "1461446703485210103287273052203988822378723970342"},
+	}
+	for _, vector := range vectors {
+		ratio, err := GetSqrtRatioAtTick(vector.tick)
+		if err != nil {
+			t.Fatalf("tick %v: %v", vector.tick, err)
+		}
+		if ratio.Dec() != vector.expected {
+			t.Errorf("tick %v: got %v, want %v", vector.tick, ratio.Dec(), vector.expected)
+		}
+	}
+
+	for _, tick := range []int{MinTick - 1, MaxTick + 1} {
+		if _, err := GetSqrtRatioAtTick(tick); err != ErrTickOutOfRange {
+			t.Errorf("tick %v: got %v, want ErrTickOutOfRange", tick, err)
+		}
+	}
+}
+
+func TestGetSqrtRatioAtTickPrecision(t *testing.T) {
+	// The v3-core spec checks these ticks against 1.0001^(tick/2); the port must stay within one unit plus 1e-15 of the exact value
+	ticks := []int{1, 10, 50, 100, 250, 500, 1000, 2500, 3000, 4000, 5000, 50000, 150000, 250000, 500000, 738203}
+	for _, magnitude := range ticks {
+		for _, tick := range []int{magnitude, -magnitude} {
+			ratio, err := GetSqrtRatioAtTick(tick)
+			if err != nil {
+				t.Fatalf("tick %v: %v", tick, err)
+			}
+			exact := exactSqrtRatioAtTick(tick)
+			difference := new(big.Float).Sub(new(big.Float).SetInt(ratio.ToBig()), exact)
+			tolerance := new(big.Float).Mul(exact, big.NewFloat(1e-15))
+			tolerance.Add(tolerance, big.NewFloat(1))
+			if difference.Abs(difference).Cmp(tolerance) > 0 {
+				t.Errorf("tick %v: got %v, exact %v", tick, ratio.Dec(), exact.Text('f', 3))
+			}
+			// Rounded up, so never below the exact value by more than the precision of the constants
+			if ratio.ToBig().Cmp(new(big.Int).Sub(mustInt(exact), big.NewInt(1))) < 0 {
+				t.Errorf("tick %v: %v rounds below %v", tick, ratio.Dec(), exact.Text('f', 3))
+			}
+		}
+	}
+}
+
+func TestGetTickAtSqrtRatio(t *testing.T) {
+	maxMinusOne := new(uint256.Int).SubUint64(MaxSqrtRatio, 1)
+	vectors := []struct {
+		sqrtPriceX96 *uint256.Int
+		expected     int
+	}{
+		{MinSqrtRatio, MinTick},
+		{uint256.MustFromDecimal("4295343490"), MinTick + 1},
+		{uint256.MustFromDecimal("1461373636630004318706518188784493106690254656249"), MaxTick - 1},
+		{maxMinusOne, MaxTick - 1},
+		{uint256.MustFromDecimal("79228162514264337593543950336"), 0},
+		{uint256.MustFromDecimal("79228162514264337593543950335"), -1},
+	}
+	for _, vector := range vectors {
+		tick, err := GetTickAtSqrtRatio(vector.sqrtPriceX96)
+		if err != nil {
+			t.Fatalf("ratio %v: %v", vector.sqrtPriceX96.Dec(), err)
+		}
+		if tick != vector.expected {
+			t.Errorf("ratio %v: got tick %v, want %v", vector.sqrtPriceX96.Dec(), tick, vector.expected)
+		}
+	}
+
+	below := new(uint256.Int).SubUint64(MinSqrtRatio, 1)
+	for _, ratio := range []*uint256.Int{below, MaxSqrtRatio} {
+		if _, err := GetTickAtSqrtRatio(ratio); err != ErrSqrtRatioOutOfRange {
+			t.Errorf("ratio %v: got %v, want ErrSqrtRatioOutOfRange", ratio.Dec(), err)
+		}
+	}
+}
+
+// FuzzGetSqrtRatioAtTick round-trips every tick through GetTickAtSqrtRatio and checks the ratios increase with the tick.
+func FuzzGetSqrtRatioAtTick(f *testing.F) {
+	for _, tick := range []int32{MinTick, MinTick + 1, -50, -1, 0, 1, 50, MaxTick - 1, MaxTick} {
+		f.Add(tick)
+	}
+	f.Fuzz(func(t *testing.T, seed int32) {
+		tick := int(seed) % (MaxTick + 1)
+		ratio, err := GetSqrtRatioAtTick(tick)
+		if err != nil {
+			t.Fatalf("tick %v: %v", tick, err)
+		}
+
+		// MaxSqrtRatio itself is outside the domain of GetTickAtSqrtRatio
+		if tick < MaxTick {
+			roundTrip, err := GetTickAtSqrtRatio(ratio)
+			if err != nil {
+				t.Fatalf("tick %v: %v", tick, err)
+			}
+			if roundTrip != tick {
+				t.Fatalf("tick %v: ratio %v maps back to tick %v", tick, ratio.Dec(), roundTrip)
+			}
+			next, err := GetSqrtRatioAtTick(tick + 1)
+			if err != nil {
+				t.Fatalf("tick %v: %v", tick+1, err)
+			}
+			if !next.Gt(ratio) {
+				t.Fatalf("tick %v: ratio %v is not below the next tick's %v", tick, ratio.Dec(), next.Dec())
+			}
+			// Anything below the next tick's ratio still maps to tick
+			belowNext := new(uint256.Int).SubUint64(next, 1)
+			if below, err := GetTickAtSqrtRatio(belowNext); err != nil || below != tick {
+				t.Fatalf("ratio %v: got tick %v (%v), want %v", belowNext.Dec(), below, err, tick)
+			}
+		}
+		if tick > MinTick {
+			belowRatio := new(uint256.Int).SubUint64(ratio, 1)
+			if below, err := GetTickAtSqrtRatio(belowRatio); err != nil || below != tick-1 {
+				t.Fatalf("ratio %v: got tick %v (%v), want %v", belowRatio.Dec(), below, err, tick-1)
+			}
+		}
+	})
+}
+
+func mustInt(value *big.Float) *big.Int {
+	integer, _ := value.Int(nil)
+	return integer
+}